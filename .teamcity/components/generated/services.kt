@@ -1,5 +1,7 @@
 // NOTE: this is Generated from the Service Definitions - manual changes will be lost
 //       to re-generate this file, run 'make generate' in the root of the repository
 var services = mapOf(
-        "keyvault" to "KeyVault"
+        "keyvault" to "KeyVault",
+        "managedidentity" to "ManagedIdentity",
+        "resource" to "Resources"
 )
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
@@ -49,6 +50,34 @@ func graphClient(authorizer auth.Authorizer, environment environments.Environmen
 }
 
 func ServicePrincipalObjectID(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, clientId string) (*string, error) {
+	ids, err := ServicePrincipalObjectIDs(ctx, authorizer, environment, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) != 1 {
+		return nil, fmt.Errorf("unexpected number of results, expected 1, received %d", len(ids))
+	}
+
+	return &ids[0], nil
+}
+
+// ServicePrincipalObjectIDs returns the Object IDs of each Service Principal with the specified Client ID
+func ServicePrincipalObjectIDs(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, clientId string) ([]string, error) {
+	return directoryObjectIDs(ctx, authorizer, environment, "/servicePrincipals", fmt.Sprintf("appId eq '%s'", escapeFilterValue(clientId)))
+}
+
+// UserObjectIDs returns the Object IDs of each User with the specified User Principal Name
+func UserObjectIDs(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, userPrincipalName string) ([]string, error) {
+	return directoryObjectIDs(ctx, authorizer, environment, "/users", fmt.Sprintf("userPrincipalName eq '%s'", escapeFilterValue(userPrincipalName)))
+}
+
+// GroupObjectIDs returns the Object IDs of each Group with the specified Display Name
+func GroupObjectIDs(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, displayName string) ([]string, error) {
+	return directoryObjectIDs(ctx, authorizer, environment, "/groups", fmt.Sprintf("displayName eq '%s'", escapeFilterValue(displayName)))
+}
+
+func directoryObjectIDs(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, path, filter string) ([]string, error) {
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithDeadline(ctx, time.Now().Add(5*time.Minute))
@@ -63,10 +92,10 @@ func ServicePrincipalObjectID(ctx context.Context, authorizer auth.Authorizer, e
 		HttpMethod: http.MethodGet,
 		OptionsObject: options{
 			query: odata.Query{
-				Filter: fmt.Sprintf("appId eq '%s'", clientId),
+				Filter: filter,
 			},
 		},
-		Path: "/servicePrincipals",
+		Path: path,
 	}
 
 	client, err := graphClient(authorizer, environment)
@@ -85,22 +114,26 @@ func ServicePrincipalObjectID(ctx context.Context, authorizer auth.Authorizer, e
 	}
 
 	model := struct {
-		ServicePrincipals []directoryObjectModel `json:"value"`
+		DirectoryObjects []directoryObjectModel `json:"value"`
 	}{}
 	if err := resp.Unmarshal(&model); err != nil {
 		return nil, fmt.Errorf("unmarshaling response: %+v", err)
 	}
 
-	if len(model.ServicePrincipals) != 1 {
-		return nil, fmt.Errorf("unexpected number of results, expected 1, received %d", len(model.ServicePrincipals))
+	ids := make([]string, 0)
+	for _, v := range model.DirectoryObjects {
+		if v.ID == nil {
+			return nil, fmt.Errorf("returned object ID was nil")
+		}
+		ids = append(ids, *v.ID)
 	}
 
-	id := model.ServicePrincipals[0].ID
-	if id == nil {
-		return nil, fmt.Errorf("returned object ID was nil")
-	}
+	return ids, nil
+}
 
-	return id, nil
+// escapeFilterValue escapes a string literal for use within an OData filter, where single quotes are doubled
+func escapeFilterValue(input string) string {
+	return strings.ReplaceAll(input, "'", "''")
 }
 
 func UserPrincipalObjectID(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment) (*string, error) {
@@ -146,3 +179,53 @@ func UserPrincipalObjectID(ctx context.Context, authorizer auth.Authorizer, envi
 
 	return model.ID, nil
 }
+
+// MemberGroupIDs returns the Object IDs of each Group which the specified Directory Object is a member of, including
+// those where membership is transitive
+func MemberGroupIDs(ctx context.Context, authorizer auth.Authorizer, environment environments.Environment, objectId string) ([]string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, time.Now().Add(5*time.Minute))
+		defer cancel()
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/json",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodPost,
+		OptionsObject: nil,
+		Path:          fmt.Sprintf("/directoryObjects/%s/getMemberGroups", objectId),
+	}
+
+	client, err := graphClient(authorizer, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("building new request: %+v", err)
+	}
+
+	if err := req.Marshal(map[string]interface{}{
+		"securityEnabledOnly": false,
+	}); err != nil {
+		return nil, fmt.Errorf("marshaling request body: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %+v", err)
+	}
+
+	model := struct {
+		GroupIds []string `json:"value"`
+	}{}
+	if err := resp.Unmarshal(&model); err != nil {
+		return nil, fmt.Errorf("unmarshaling response: %+v", err)
+	}
+
+	return model.GroupIds, nil
+}
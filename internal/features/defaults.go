@@ -13,6 +13,9 @@ func Default() UserFeatures {
 			RecoverSoftDeletedKeys:           true,
 			RecoverSoftDeletedCerts:          true,
 			RecoverSoftDeletedSecrets:        true,
+
+			CancelPendingCertificateOperationsOnFailure: false,
+			VaultReadinessProbe:                         VaultReadinessProbeDataPlane,
 		},
 	}
 }
@@ -6,6 +6,17 @@ type UserFeatures struct {
 	TemplateDeployment TemplateDeploymentFeatures
 }
 
+const (
+	// VaultReadinessProbeDataPlane makes an authenticated request to the Key Vault data plane
+	VaultReadinessProbeDataPlane = "DataPlane"
+
+	// VaultReadinessProbeDnsResolution checks that the hostname for the Key Vault can be resolved
+	VaultReadinessProbeDnsResolution = "DnsResolution"
+
+	// VaultReadinessProbeSkip skips checking whether the Key Vault data plane is available
+	VaultReadinessProbeSkip = "Skip"
+)
+
 type KeyVaultFeatures struct {
 	PurgeSoftDeleteOnDestroy         bool
 	PurgeSoftDeletedKeysOnDestroy    bool
@@ -16,6 +27,22 @@ type KeyVaultFeatures struct {
 	RecoverSoftDeletedKeys           bool
 	RecoverSoftDeletedCerts          bool
 	RecoverSoftDeletedSecrets        bool
+
+	// CancelPendingCertificateOperationsOnFailure cancels (and deletes) the pending Certificate Operation
+	// when a Key Vault Certificate fails to be issued, so that it doesn't block subsequent applies
+	CancelPendingCertificateOperationsOnFailure bool
+
+	// VaultReadinessProbe determines how (or whether) the Key Vault data plane is checked for availability
+	// after creating a Key Vault (or recovering a nested item)
+	VaultReadinessProbe string
+
+	// the following are opt-in policies which are enforced at plan time for
+	// Key Vault Certificates, Keys and Secrets - the zero value disables each check
+	RequireExpirationDate bool
+	MaximumValidityPeriod string
+	RequiredTags          []string
+	MinimumRSAKeySize     int
+	AllowedKeyCurves      []string
 }
 
 type ResourceGroupFeatures struct {
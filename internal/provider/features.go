@@ -4,8 +4,11 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -96,6 +99,71 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional:    true,
 						Default:     true,
 					},
+
+					"cancel_pending_certificate_operations_on_failure": {
+						Description: "When enabled the pending Certificate Operation for an `azurerm_key_vault_certificate` resource will be cancelled and deleted, when the Certificate fails to be issued",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+
+					"vault_readiness_probe": {
+						Description: "The probe used to determine whether the data plane of a `azurerm_key_vault` (or a recovered Key Vault Certificate, Key or Secret) is available",
+						Type:        pluginsdk.TypeString,
+						Optional:    true,
+						Default:     features.VaultReadinessProbeDataPlane,
+						ValidateFunc: validation.StringInSlice([]string{
+							features.VaultReadinessProbeDataPlane,
+							features.VaultReadinessProbeDnsResolution,
+							features.VaultReadinessProbeSkip,
+						}, false),
+					},
+
+					"require_expiration_date": {
+						Description: "When enabled `azurerm_key_vault_key` and `azurerm_key_vault_secret` resources must specify an `expiration_date`",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+
+					"maximum_validity_period": {
+						Description:  "The maximum validity period (as an ISO8601 duration) allowed for `azurerm_key_vault_certificate`, `azurerm_key_vault_key` and `azurerm_key_vault_secret` resources",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validate.ISO8601Duration,
+					},
+
+					"required_tags": {
+						Description: "A list of tag names which must be specified on `azurerm_key_vault_certificate`, `azurerm_key_vault_key` and `azurerm_key_vault_secret` resources",
+						Type:        pluginsdk.TypeList,
+						Optional:    true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"minimum_rsa_key_size": {
+						Description:  "The minimum key size allowed for RSA keys used by `azurerm_key_vault_certificate` and `azurerm_key_vault_key` resources",
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntInSlice([]int{0, 2048, 3072, 4096}),
+					},
+
+					"allowed_key_curves": {
+						Description: "A list of elliptic curves allowed for EC keys used by `azurerm_key_vault_certificate` and `azurerm_key_vault_key` resources",
+						Type:        pluginsdk.TypeList,
+						Optional:    true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(keyvault.JSONWebKeyCurveNameP256),
+								string(keyvault.JSONWebKeyCurveNameP256K),
+								string(keyvault.JSONWebKeyCurveNameP384),
+								string(keyvault.JSONWebKeyCurveNameP521),
+							}, false),
+						},
+					},
 				},
 			},
 		},
@@ -180,6 +248,31 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := keyVaultRaw["recover_soft_deleted_secrets"]; ok {
 				featuresMap.KeyVault.RecoverSoftDeletedSecrets = v.(bool)
 			}
+			if v, ok := keyVaultRaw["cancel_pending_certificate_operations_on_failure"]; ok {
+				featuresMap.KeyVault.CancelPendingCertificateOperationsOnFailure = v.(bool)
+			}
+			if v, ok := keyVaultRaw["vault_readiness_probe"]; ok {
+				featuresMap.KeyVault.VaultReadinessProbe = v.(string)
+			}
+			if v, ok := keyVaultRaw["require_expiration_date"]; ok {
+				featuresMap.KeyVault.RequireExpirationDate = v.(bool)
+			}
+			if v, ok := keyVaultRaw["maximum_validity_period"]; ok {
+				featuresMap.KeyVault.MaximumValidityPeriod = v.(string)
+			}
+			if v, ok := keyVaultRaw["required_tags"]; ok {
+				for _, tag := range v.([]interface{}) {
+					featuresMap.KeyVault.RequiredTags = append(featuresMap.KeyVault.RequiredTags, tag.(string))
+				}
+			}
+			if v, ok := keyVaultRaw["minimum_rsa_key_size"]; ok {
+				featuresMap.KeyVault.MinimumRSAKeySize = v.(int)
+			}
+			if v, ok := keyVaultRaw["allowed_key_curves"]; ok {
+				for _, curve := range v.([]interface{}) {
+					featuresMap.KeyVault.AllowedKeyCurves = append(featuresMap.KeyVault.AllowedKeyCurves, curve.(string))
+				}
+			}
 		}
 	}
 
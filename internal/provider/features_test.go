@@ -28,6 +28,9 @@ func TestExpandFeatures(t *testing.T) {
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
 					RecoverSoftDeletedSecrets:        true,
+
+					CancelPendingCertificateOperationsOnFailure: false,
+					VaultReadinessProbe:                         "DataPlane",
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
@@ -52,6 +55,13 @@ func TestExpandFeatures(t *testing.T) {
 							"recover_soft_deleted_keys":                               true,
 							"recover_soft_deleted_key_vaults":                         true,
 							"recover_soft_deleted_secrets":                            true,
+							"cancel_pending_certificate_operations_on_failure":        true,
+							"vault_readiness_probe":                                   "Skip",
+							"require_expiration_date":                                 true,
+							"maximum_validity_period":                                 "P1Y",
+							"required_tags":                                           []interface{}{"owner", "environment"},
+							"minimum_rsa_key_size":                                    3072,
+							"allowed_key_curves":                                      []interface{}{"P-384", "P-521"},
 						},
 					},
 					"resource_group": []interface{}{
@@ -77,6 +87,14 @@ func TestExpandFeatures(t *testing.T) {
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
 					RecoverSoftDeletedSecrets:        true,
+					RequireExpirationDate:            true,
+					MaximumValidityPeriod:            "P1Y",
+					RequiredTags:                     []string{"owner", "environment"},
+					MinimumRSAKeySize:                3072,
+					AllowedKeyCurves:                 []string{"P-384", "P-521"},
+
+					CancelPendingCertificateOperationsOnFailure: true,
+					VaultReadinessProbe:                         "Skip",
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
@@ -101,6 +119,13 @@ func TestExpandFeatures(t *testing.T) {
 							"recover_soft_deleted_keys":                               false,
 							"recover_soft_deleted_key_vaults":                         false,
 							"recover_soft_deleted_secrets":                            false,
+							"cancel_pending_certificate_operations_on_failure":        false,
+							"vault_readiness_probe":                                   "DataPlane",
+							"require_expiration_date":                                 false,
+							"maximum_validity_period":                                 "",
+							"required_tags":                                           []interface{}{},
+							"minimum_rsa_key_size":                                    0,
+							"allowed_key_curves":                                      []interface{}{},
 						},
 					},
 					"resource_group": []interface{}{
@@ -126,6 +151,9 @@ func TestExpandFeatures(t *testing.T) {
 					RecoverSoftDeletedKeys:           false,
 					RecoverSoftDeletedKeyVaults:      false,
 					RecoverSoftDeletedSecrets:        false,
+
+					CancelPendingCertificateOperationsOnFailure: false,
+					VaultReadinessProbe:                         "DataPlane",
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
@@ -171,6 +199,9 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
 					RecoverSoftDeletedSecrets:        true,
+
+					CancelPendingCertificateOperationsOnFailure: false,
+					VaultReadinessProbe:                         "DataPlane",
 				},
 			},
 		},
@@ -189,6 +220,13 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 							"recover_soft_deleted_keys":                               true,
 							"recover_soft_deleted_key_vaults":                         true,
 							"recover_soft_deleted_secrets":                            true,
+							"cancel_pending_certificate_operations_on_failure":        true,
+							"vault_readiness_probe":                                   "Skip",
+							"require_expiration_date":                                 true,
+							"maximum_validity_period":                                 "P1Y",
+							"required_tags":                                           []interface{}{"owner", "environment"},
+							"minimum_rsa_key_size":                                    3072,
+							"allowed_key_curves":                                      []interface{}{"P-384", "P-521"},
 						},
 					},
 				},
@@ -204,6 +242,14 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					RecoverSoftDeletedKeys:           true,
 					RecoverSoftDeletedKeyVaults:      true,
 					RecoverSoftDeletedSecrets:        true,
+					RequireExpirationDate:            true,
+					MaximumValidityPeriod:            "P1Y",
+					RequiredTags:                     []string{"owner", "environment"},
+					MinimumRSAKeySize:                3072,
+					AllowedKeyCurves:                 []string{"P-384", "P-521"},
+
+					CancelPendingCertificateOperationsOnFailure: true,
+					VaultReadinessProbe:                         "Skip",
 				},
 			},
 		},
@@ -222,6 +268,13 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 							"recover_soft_deleted_keys":                               false,
 							"recover_soft_deleted_key_vaults":                         false,
 							"recover_soft_deleted_secrets":                            false,
+							"cancel_pending_certificate_operations_on_failure":        false,
+							"vault_readiness_probe":                                   "DataPlane",
+							"require_expiration_date":                                 false,
+							"maximum_validity_period":                                 "",
+							"required_tags":                                           []interface{}{},
+							"minimum_rsa_key_size":                                    0,
+							"allowed_key_curves":                                      []interface{}{},
 						},
 					},
 				},
@@ -237,6 +290,9 @@ func TestExpandFeaturesKeyVault(t *testing.T) {
 					RecoverSoftDeletedKeyVaults:      false,
 					RecoverSoftDeletedKeys:           false,
 					RecoverSoftDeletedSecrets:        false,
+
+					CancelPendingCertificateOperationsOnFailure: false,
+					VaultReadinessProbe:                         "DataPlane",
 				},
 			},
 		},
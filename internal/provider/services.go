@@ -2,24 +2,18 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault"
 )
 
 //go:generate go run ../tools/generator-services/main.go -path=../../
 
+// SupportedTypedServices returns the Typed Service Registrations for each Service Package, which are
+// discovered (and generated into services_gen.go) by the `generator-services` tool
 func SupportedTypedServices() []sdk.TypedServiceRegistration {
-	services := []sdk.TypedServiceRegistration{
-		keyvault.Registration{},
-	}
-	services = append(services, autoRegisteredTypedServices()...)
-	return services
+	return autoRegisteredTypedServices()
 }
 
+// SupportedUntypedServices returns the Untyped Service Registrations for each Service Package, which are
+// discovered (and generated into services_gen.go) by the `generator-services` tool
 func SupportedUntypedServices() []sdk.UntypedServiceRegistration {
-	return func() []sdk.UntypedServiceRegistration {
-		out := []sdk.UntypedServiceRegistration{
-			keyvault.Registration{},
-		}
-		return out
-	}()
+	return autoRegisteredUntypedServices()
 }
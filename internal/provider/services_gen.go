@@ -4,8 +4,23 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedidentity"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource"
 )
 
 func autoRegisteredTypedServices() []sdk.TypedServiceRegistration {
-	return []sdk.TypedServiceRegistration{}
+	return []sdk.TypedServiceRegistration{
+		keyvault.Registration{},
+		managedidentity.Registration{},
+		resource.Registration{},
+	}
+}
+
+func autoRegisteredUntypedServices() []sdk.UntypedServiceRegistration {
+	return []sdk.UntypedServiceRegistration{
+		keyvault.Registration{},
+		managedidentity.Registration{},
+		resource.Registration{},
+	}
 }
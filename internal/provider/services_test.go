@@ -1,11 +1,107 @@
 package provider
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
+func TestServiceRegistrationsAreWiredIntoTheProvider(t *testing.T) {
+	typed := make(map[string]struct{})
+	for _, service := range SupportedTypedServices() {
+		typed[reflect.TypeOf(service).PkgPath()] = struct{}{}
+	}
+	untyped := make(map[string]struct{})
+	for _, service := range SupportedUntypedServices() {
+		untyped[reflect.TypeOf(service).PkgPath()] = struct{}{}
+	}
+
+	// a Service Registration implementing both interfaces should be registered as both
+	for _, service := range SupportedTypedServices() {
+		if _, ok := service.(sdk.UntypedServiceRegistration); ok {
+			if _, registered := untyped[reflect.TypeOf(service).PkgPath()]; !registered {
+				t.Fatalf("the Service Registration in %q isn't registered as an Untyped Service", reflect.TypeOf(service).PkgPath())
+			}
+		}
+	}
+	for _, service := range SupportedUntypedServices() {
+		if _, ok := service.(sdk.TypedServiceRegistration); ok {
+			if _, registered := typed[reflect.TypeOf(service).PkgPath()]; !registered {
+				t.Fatalf("the Service Registration in %q isn't registered as a Typed Service", reflect.TypeOf(service).PkgPath())
+			}
+		}
+	}
+
+	servicesDirectory := filepath.Join("..", "services")
+	entries, err := os.ReadDir(servicesDirectory)
+	if err != nil {
+		t.Fatalf("listing the Service Packages: %+v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		t.Logf("Service Package %q..", entry.Name())
+		types, err := registrationTypesInPackage(filepath.Join(servicesDirectory, entry.Name()))
+		if err != nil {
+			t.Fatalf("parsing the Service Package %q: %+v", entry.Name(), err)
+		}
+
+		_, hasRegistration := types["Registration"]
+		if _, ok := types["autoRegistration"]; ok && !hasRegistration {
+			t.Fatalf("the Service Package %q contains an `autoRegistration` which must be exposed via a `Registration`", entry.Name())
+		}
+		if !hasRegistration {
+			continue
+		}
+
+		pkgPath := "github.com/hashicorp/terraform-provider-azurerm/internal/services/" + entry.Name()
+		_, isTyped := typed[pkgPath]
+		_, isUntyped := untyped[pkgPath]
+		if !isTyped && !isUntyped {
+			t.Fatalf("the Registration for the Service Package %q isn't registered in the Provider - run `make generate` to update `services_gen.go`", entry.Name())
+		}
+	}
+}
+
+// registrationTypesInPackage returns the names of the Registration types (`Registration` and `autoRegistration`)
+// declared within the Go package in the specified directory
+func registrationTypesInPackage(directory string) (map[string]struct{}, error) {
+	packages, err := parser.ParseDir(token.NewFileSet(), directory, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]struct{})
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok && (typeSpec.Name.Name == "Registration" || typeSpec.Name.Name == "autoRegistration") {
+						out[typeSpec.Name.Name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
 func TestTypedDataSourcesContainValidModelObjects(t *testing.T) {
 	for _, service := range SupportedTypedServices() {
 		t.Logf("Service %q..", service.Name())
@@ -32,6 +128,32 @@ func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	}
 }
 
+func TestTypedDataSourcesModelObjectsMatchSchema(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, dataSource := range service.DataSources() {
+			t.Logf("- DataSource %q..", dataSource.ResourceType())
+			wrapper := sdk.NewDataSourceWrapper(dataSource)
+			if _, err := wrapper.DataSource(); err != nil {
+				t.Fatalf("building Data Source %q: %+v", dataSource.ResourceType(), err)
+			}
+		}
+	}
+}
+
+func TestTypedResourcesModelObjectsMatchSchema(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, resource := range service.Resources() {
+			t.Logf("- Resource %q..", resource.ResourceType())
+			wrapper := sdk.NewResourceWrapper(resource)
+			if _, err := wrapper.Resource(); err != nil {
+				t.Fatalf("building Resource %q: %+v", resource.ResourceType(), err)
+			}
+		}
+	}
+}
+
 func TestTypedResourcesContainValidIDParsers(t *testing.T) {
 	// This test confirms that all of the Typed Resources return an ID Validation method
 	// which is used to ensure that each of the resources will validate the Resource ID
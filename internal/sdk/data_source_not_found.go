@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// DataSourceNotFoundBehaviour determines how a Data Source behaves when the object being looked up doesn't exist
+type DataSourceNotFoundBehaviour string
+
+const (
+	// DataSourceNotFoundBehaviourError raises an error when the object doesn't exist
+	DataSourceNotFoundBehaviourError DataSourceNotFoundBehaviour = "error"
+
+	// DataSourceNotFoundBehaviourEmpty sets `exists` to false and leaves the other attributes empty
+	// when the object doesn't exist
+	DataSourceNotFoundBehaviourEmpty DataSourceNotFoundBehaviour = "empty"
+)
+
+const (
+	notFoundBehaviourArgument = "not_found_behaviour"
+	existsAttribute           = "exists"
+)
+
+var _ error = &NotFoundError{}
+
+// NotFoundError is returned from the Read function of a Data Source when the object doesn't exist
+type NotFoundError struct {
+	// Id is the ID of the object which doesn't exist
+	Id resourceids.Id
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s was not found", e.Id)
+}
+
+// NotFound returns a NotFoundError for the specified ID, which should be returned from the Read function
+// of a Data Source when the object doesn't exist - see DataSourceWithNotFoundBehaviour
+func (rmd ResourceMetaData) NotFound(id resourceids.Id) error {
+	return &NotFoundError{
+		Id: id,
+	}
+}
+
+// notFoundBehaviourSchema adds the `not_found_behaviour` argument and the `exists` attribute to the Schema
+func notFoundBehaviourSchema(input map[string]*schema.Schema, defaultBehaviour DataSourceNotFoundBehaviour) error {
+	for _, k := range []string{notFoundBehaviourArgument, existsAttribute} {
+		if _, alreadyExists := input[k]; alreadyExists {
+			return fmt.Errorf("%q already exists in the schema", k)
+		}
+	}
+
+	input[notFoundBehaviourArgument] = &schema.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+		Default:  string(defaultBehaviour),
+		ValidateFunc: validation.StringInSlice([]string{
+			string(DataSourceNotFoundBehaviourError),
+			string(DataSourceNotFoundBehaviourEmpty),
+		}, false),
+	}
+	input[existsAttribute] = &schema.Schema{
+		Type:     pluginsdk.TypeBool,
+		Computed: true,
+	}
+	return nil
+}
+
+// handleNotFoundBehaviour sets the `exists` attribute based on the result of the Read function - returning
+// the error unless the object wasn't found and `not_found_behaviour` is set to `empty`
+func handleNotFoundBehaviour(metadata ResourceMetaData, readErr error) error {
+	d := metadata.ResourceData
+	if readErr == nil {
+		return d.Set(existsAttribute, true)
+	}
+
+	var notFound *NotFoundError
+	if !errors.As(readErr, &notFound) || DataSourceNotFoundBehaviour(d.Get(notFoundBehaviourArgument).(string)) != DataSourceNotFoundBehaviourEmpty {
+		return readErr
+	}
+
+	metadata.Logger.Debugf("%s was not found - returning an empty result", notFound.Id)
+	d.SetId(notFound.Id.ID())
+	return d.Set(existsAttribute, false)
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type notFoundDataSource struct {
+	err error
+}
+
+type notFoundDataSourceModel struct {
+	Name string `tfschema:"name"`
+}
+
+var _ DataSourceWithNotFoundBehaviour = notFoundDataSource{}
+
+func (d notFoundDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (d notFoundDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (d notFoundDataSource) ModelObject() interface{} {
+	// the model doesn't include the `not_found_behaviour` and `exists` fields, since these are managed by the wrapper
+	return &notFoundDataSourceModel{}
+}
+
+func (d notFoundDataSource) ResourceType() string {
+	return "validator_not_found"
+}
+
+func (d notFoundDataSource) Read() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			if d.err != nil {
+				return d.err
+			}
+
+			metadata.SetID(fakeResourceId{})
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (d notFoundDataSource) DefaultNotFoundBehaviour() DataSourceNotFoundBehaviour {
+	return DataSourceNotFoundBehaviourError
+}
+
+func TestDataSourceWrapperNotFoundBehaviour(t *testing.T) {
+	testData := []struct {
+		behaviour string
+		err       error
+		expectErr bool
+		exists    bool
+	}{
+		{
+			behaviour: "",
+			exists:    true,
+		},
+		{
+			behaviour: "empty",
+			exists:    true,
+		},
+		{
+			behaviour: "",
+			err:       ResourceMetaData{}.NotFound(fakeResourceId{}),
+			expectErr: true,
+		},
+		{
+			behaviour: "error",
+			err:       ResourceMetaData{}.NotFound(fakeResourceId{}),
+			expectErr: true,
+		},
+		{
+			behaviour: "empty",
+			err:       fmt.Errorf("wrapped: %w", ResourceMetaData{}.NotFound(fakeResourceId{})),
+			exists:    false,
+		},
+		{
+			// other errors should always be surfaced
+			behaviour: "empty",
+			err:       fmt.Errorf("retrieving: 500 Internal Server Error"),
+			expectErr: true,
+		},
+	}
+	for _, v := range testData {
+		wrapper := NewDataSourceWrapper(notFoundDataSource{err: v.err})
+		dataSource, err := wrapper.DataSource()
+		if err != nil {
+			t.Fatalf("wrapping Data Source: %+v", err)
+		}
+		if _, ok := dataSource.Schema["not_found_behaviour"]; !ok {
+			t.Fatalf("expected the `not_found_behaviour` argument to be added to the Schema")
+		}
+
+		raw := map[string]interface{}{
+			"name": "example",
+		}
+		if v.behaviour != "" {
+			raw["not_found_behaviour"] = v.behaviour
+		}
+		d := schema.TestResourceDataRaw(t, dataSource.Schema, raw)
+
+		diags := dataSource.ReadContext(context.TODO(), d, &clients.Client{})
+		if v.expectErr {
+			if !diags.HasError() {
+				t.Fatalf("expected an error for %+v", v)
+			}
+			continue
+		}
+		if diags.HasError() {
+			t.Fatalf("expected no error for %+v but got %+v", v, diags)
+		}
+
+		if d.Id() != "/some/id" {
+			t.Fatalf("expected the ID to be set but got %q", d.Id())
+		}
+		if actual := d.Get("exists").(bool); actual != v.exists {
+			t.Fatalf("expected `exists` to be %t but got %t", v.exists, actual)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var _ error = &AttributeError{}
+
+// AttributeError is an error relating to a specific attribute, which allows Terraform to highlight the
+// offending line in the configuration - for example when a value is rejected by the API
+type AttributeError struct {
+	// Path is the path to the attribute in the Schema, for example `rotation_policy.0.expire_after`
+	Path string
+
+	// Summary is a short description of the error
+	Summary string
+
+	// Detail is an (optional) longer description of the error, for example how to resolve it
+	Detail string
+}
+
+// NewAttributeError returns an AttributeError for the attribute at the specified path
+func NewAttributeError(path, summary, detail string) *AttributeError {
+	return &AttributeError{
+		Path:    path,
+		Summary: summary,
+		Detail:  detail,
+	}
+}
+
+func (e *AttributeError) Error() string {
+	out := e.Summary
+	if e.Path != "" {
+		out = e.Path + ": " + out
+	}
+	if e.Detail != "" {
+		out += "\n\n" + e.Detail
+	}
+	return out
+}
+
+// AddAttributeWarning emits a warning diagnostic for the attribute at the specified path, for example
+// `rotation_policy.0.expire_after` - falling back to logging the warning when diagnostics aren't available
+//
+// NOTE: the Plugin SDK can't surface warnings during CustomizeDiff/ValidateConfig or an Import, as such
+// warnings raised during these operations are returned as an error rather than being silently dropped
+func (rmd ResourceMetaData) AddAttributeWarning(path, summary, detail string) {
+	if v, ok := rmd.Logger.(*DiagnosticsLogger); ok {
+		v.appendAttributeWarning(path, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: attributePathFromString(path),
+		})
+		return
+	}
+
+	if rmd.Logger != nil {
+		rmd.Logger.Warnf("%s: %s %s", path, summary, detail)
+	}
+}
+
+// diagnosticForError returns a Diagnostic for the error, using the Path, Summary and Detail from
+// an AttributeError when one is present within the chain of errors
+func diagnosticForError(err error) diag.Diagnostic {
+	var attributeErr *AttributeError
+	if errors.As(err, &attributeErr) {
+		detail := attributeErr.Detail
+		if err != error(attributeErr) {
+			// the error has been wrapped, so the additional context is surfaced in the Detail - the Path
+			// and Summary are already part of the Diagnostic, so these are removed from the wrapping context
+			wrappingContext := strings.Replace(err.Error(), attributeErr.Error(), "", 1)
+			wrappingContext = strings.TrimSpace(strings.Trim(strings.TrimSpace(wrappingContext), ":"))
+			if wrappingContext != "" {
+				detail = strings.TrimSpace(wrappingContext + "\n\n" + attributeErr.Detail)
+			}
+		}
+
+		return diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       attributeErr.Summary,
+			Detail:        detail,
+			AttributePath: attributePathFromString(attributeErr.Path),
+		}
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+	}
+}
+
+// attributePathFromString converts a flattened path (e.g. `rotation_policy.0.expire_after`) into a cty.Path
+func attributePathFromString(input string) cty.Path {
+	if input == "" {
+		return nil
+	}
+
+	path := cty.Path{}
+	for _, segment := range strings.Split(input, ".") {
+		if index, err := strconv.Atoi(segment); err == nil {
+			path = path.IndexInt(index)
+			continue
+		}
+
+		path = path.GetAttr(segment)
+	}
+	return path
+}
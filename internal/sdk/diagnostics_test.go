@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDiagnosticForError(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    error
+		expected diag.Diagnostic
+	}{
+		{
+			name:  "plain error",
+			input: fmt.Errorf("retrieving Key: boom"),
+			expected: diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "retrieving Key: boom",
+			},
+		},
+		{
+			name:  "attribute error",
+			input: NewAttributeError("rotation_policy.0.expire_after", "invalid duration", "must be at least 28 days"),
+			expected: diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid duration",
+				Detail:        "must be at least 28 days",
+				AttributePath: cty.GetAttrPath("rotation_policy").IndexInt(0).GetAttr("expire_after"),
+			},
+		},
+		{
+			name:  "wrapped attribute error",
+			input: fmt.Errorf("updating Key: %w", NewAttributeError("name", "invalid name", "")),
+			expected: diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid name",
+				Detail:        "updating Key",
+				AttributePath: cty.GetAttrPath("name"),
+			},
+		},
+		{
+			name:  "wrapped attribute error with a detail",
+			input: fmt.Errorf("updating Key %q: %w", "example", NewAttributeError("rotation_policy.0.expire_after", "invalid duration", "must be at least 28 days")),
+			expected: diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid duration",
+				Detail:        "updating Key \"example\"\n\nmust be at least 28 days",
+				AttributePath: cty.GetAttrPath("rotation_policy").IndexInt(0).GetAttr("expire_after"),
+			},
+		},
+		{
+			name:  "attribute error wrapped multiple times",
+			input: fmt.Errorf("updating Key: %w", fmt.Errorf("building payload: %w", NewAttributeError("name", "invalid name", "must be lowercase"))),
+			expected: diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid name",
+				Detail:        "updating Key: building payload\n\nmust be lowercase",
+				AttributePath: cty.GetAttrPath("name"),
+			},
+		},
+		{
+			name:  "attribute error without a path",
+			input: NewAttributeError("", "something went wrong", "details"),
+			expected: diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "something went wrong",
+				Detail:   "details",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := diagnosticForError(v.input)
+		if actual.Severity != v.expected.Severity || actual.Summary != v.expected.Summary || actual.Detail != v.expected.Detail {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+		if !actual.AttributePath.Equals(v.expected.AttributePath) {
+			t.Fatalf("expected the path %+v but got %+v", v.expected.AttributePath, actual.AttributePath)
+		}
+	}
+}
+
+func TestDiagnosticsLoggerMergesWarnings(t *testing.T) {
+	logger := &DiagnosticsLogger{}
+	metadata := ResourceMetaData{
+		Logger: logger,
+	}
+
+	logger.Warn("the `legacy` field is deprecated")
+	logger.Warnf("the %s field is deprecated", "`legacy`")
+	metadata.AddAttributeWarning("rotation_policy.0.expire_after", "short expiry", "")
+	metadata.AddAttributeWarning("rotation_policy.0.expire_after", "short expiry", "")
+	metadata.AddAttributeWarning("rotation_policy.1.expire_after", "short expiry", "")
+
+	if len(logger.diagnostics) != 3 {
+		t.Fatalf("expected 3 diagnostics but got %d: %+v", len(logger.diagnostics), logger.diagnostics)
+	}
+	for _, v := range logger.diagnostics {
+		if v.Severity != diag.Warning {
+			t.Fatalf("expected a warning but got %+v", v)
+		}
+	}
+	if !logger.diagnostics[2].AttributePath.Equals(cty.GetAttrPath("rotation_policy").IndexInt(1).GetAttr("expire_after")) {
+		t.Fatalf("unexpected path %+v", logger.diagnostics[2].AttributePath)
+	}
+}
+
+func TestDiagnosticsWrapperUsesALoggerPerOperation(t *testing.T) {
+	calls := 0
+	wrapped := diagnosticsWrapper("create", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+		calls++
+		logger.Warnf("warning %d", calls)
+		if calls == 2 {
+			return NewAttributeError("name", "invalid name", "")
+		}
+		return nil
+	}, &DiagnosticsLogger{})
+
+	first := wrapped(context.TODO(), nil, nil)
+	if len(first) != 1 || first[0].Summary != "warning 1" || first[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning for the first operation but got %+v", first)
+	}
+
+	second := wrapped(context.TODO(), nil, nil)
+	if len(second) != 2 {
+		t.Fatalf("expected an error and a warning for the second operation but got %+v", second)
+	}
+	if second[0].Severity != diag.Error || !second[0].AttributePath.Equals(cty.GetAttrPath("name")) {
+		t.Fatalf("expected an error for `name` but got %+v", second[0])
+	}
+	if second[1].Summary != "warning 2" {
+		t.Fatalf("expected only the warning from the second operation but got %+v", second[1])
+	}
+}
+
+func TestDiagnosticsLoggerWithoutDiagnosticsRejectsAttributeWarnings(t *testing.T) {
+	logger := newOperationLoggerWithoutDiagnostics(context.TODO(), &DiagnosticsLogger{}, operationLogFields("customize_diff", ""))
+	metadata := ResourceMetaData{
+		Logger: logger.WithFields(map[string]interface{}{
+			"example": "value",
+		}),
+	}
+
+	metadata.Logger.Warn("the `legacy` field is deprecated")
+	if err := rejectedWarningsError("customize_diff", logger); err != nil {
+		t.Fatalf("expected warnings which aren't for an attribute to only be logged but got: %+v", err)
+	}
+
+	metadata.AddAttributeWarning("rotation_policy.0.expire_after", "short expiry", "")
+	err := rejectedWarningsError("customize_diff", logger)
+	if err == nil {
+		t.Fatalf("expected an error for the attribute warning")
+	}
+	if !strings.Contains(err.Error(), "rotation_policy.0.expire_after: short expiry") {
+		t.Fatalf("expected the error to contain the attribute warning but got: %+v", err)
+	}
+	if v := logger.(*DiagnosticsLogger).diagnostics; len(v) != 0 {
+		t.Fatalf("expected no diagnostics to be collected but got %+v", v)
+	}
+}
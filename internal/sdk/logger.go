@@ -1,7 +1,31 @@
 package sdk
 
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
 // Logger is an interface for switching out the Logger implementation
 type Logger interface {
+	// Trace prints out a message prefixed with `[TRACE]` verbatim
+	Trace(message string)
+
+	// Tracef prints out a message prefixed with `[TRACE]` formatted
+	// with the specified arguments
+	Tracef(format string, args ...interface{})
+
+	// Debug prints out a message prefixed with `[DEBUG]` verbatim
+	Debug(message string)
+
+	// Debugf prints out a message prefixed with `[DEBUG]` formatted
+	// with the specified arguments
+	Debugf(format string, args ...interface{})
+
 	// Info prints out a message prefixed with `[INFO]` verbatim
 	Info(message string)
 
@@ -15,4 +39,113 @@ type Logger interface {
 	// Warnf prints out a message prefixed with `[WARN]` formatted
 	// with the specified arguments
 	Warnf(format string, args ...interface{})
+
+	// WithFields returns a Logger which includes the specified key/value pairs
+	// (for example the Resource ID) alongside each message
+	WithFields(fields map[string]interface{}) Logger
+}
+
+type logLevel string
+
+const (
+	logLevelTrace logLevel = "TRACE"
+	logLevelDebug logLevel = "DEBUG"
+	logLevelInfo  logLevel = "INFO"
+	logLevelWarn  logLevel = "WARN"
+)
+
+// printLog writes the message prefixed with the level using the standard library's log package
+func printLog(level logLevel, message string, fields map[string]interface{}) {
+	log.Printf("[%s] %s%s", level, message, formatLogFields(fields))
+}
+
+// writeLog writes the message to the Terraform log via tflog using the context for the current request
+func writeLog(ctx context.Context, level logLevel, message string, fields map[string]interface{}) {
+	switch level {
+	case logLevelTrace:
+		tflog.Trace(ctx, message, fields)
+	case logLevelDebug:
+		tflog.Debug(ctx, message, fields)
+	case logLevelInfo:
+		tflog.Info(ctx, message, fields)
+	default:
+		tflog.Warn(ctx, message, fields)
+	}
+}
+
+// formatLogFields returns the fields as ` key=value` pairs sorted by key, so that the output is stable
+func formatLogFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return ": " + strings.Join(out, " ")
+}
+
+// mergeLogFields returns a new map containing the existing fields overlaid with the additional fields
+func mergeLogFields(existing map[string]interface{}, additional map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(existing)+len(additional))
+	for k, v := range existing {
+		out[k] = v
+	}
+	for k, v := range additional {
+		out[k] = v
+	}
+	return out
+}
+
+var _ Logger = traceLogger{}
+
+// traceLogger writes every message at the Trace level, regardless of the level it's logged at - which
+// is used for the (verbose) output from serializing and deserializing the Model objects
+type traceLogger struct {
+	logger Logger
+}
+
+func (l traceLogger) Trace(message string) {
+	l.logger.Trace(message)
+}
+
+func (l traceLogger) Tracef(format string, args ...interface{}) {
+	l.logger.Tracef(format, args...)
+}
+
+func (l traceLogger) Debug(message string) {
+	l.logger.Trace(message)
+}
+
+func (l traceLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Tracef(format, args...)
+}
+
+func (l traceLogger) Info(message string) {
+	l.logger.Trace(message)
+}
+
+func (l traceLogger) Infof(format string, args ...interface{}) {
+	l.logger.Tracef(format, args...)
+}
+
+func (l traceLogger) Warn(message string) {
+	l.logger.Trace(message)
+}
+
+func (l traceLogger) Warnf(format string, args ...interface{}) {
+	l.logger.Tracef(format, args...)
+}
+
+func (l traceLogger) WithFields(fields map[string]interface{}) Logger {
+	return traceLogger{
+		logger: l.logger.WithFields(fields),
+	}
 }
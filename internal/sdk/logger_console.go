@@ -2,18 +2,41 @@ package sdk
 
 import (
 	"fmt"
-	"log"
 )
 
 var _ Logger = ConsoleLogger{}
 
 // ConsoleLogger provides a Logger implementation which writes the log messages
 // to StdOut - in Terraform's perspective that's proxied via the Plugin SDK
-type ConsoleLogger struct{}
+type ConsoleLogger struct {
+	fields map[string]interface{}
+}
+
+// Trace prints out a message prefixed with `[TRACE]` verbatim
+func (l ConsoleLogger) Trace(message string) {
+	printLog(logLevelTrace, message, l.fields)
+}
+
+// Tracef prints out a message prefixed with `[TRACE]` formatted
+// with the specified arguments
+func (l ConsoleLogger) Tracef(format string, args ...interface{}) {
+	l.Trace(fmt.Sprintf(format, args...))
+}
+
+// Debug prints out a message prefixed with `[DEBUG]` verbatim
+func (l ConsoleLogger) Debug(message string) {
+	printLog(logLevelDebug, message, l.fields)
+}
+
+// Debugf prints out a message prefixed with `[DEBUG]` formatted
+// with the specified arguments
+func (l ConsoleLogger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
+}
 
 // Info prints out a message prefixed with `[INFO]` verbatim
-func (ConsoleLogger) Info(message string) {
-	log.Printf("[INFO] %s", message)
+func (l ConsoleLogger) Info(message string) {
+	printLog(logLevelInfo, message, l.fields)
 }
 
 // Infof prints out a message prefixed with `[INFO]` formatted
@@ -24,7 +47,7 @@ func (l ConsoleLogger) Infof(format string, args ...interface{}) {
 
 // Warn prints out a message prefixed with `[WARN]` formatted verbatim
 func (l ConsoleLogger) Warn(message string) {
-	log.Printf("[WARN] %s", message)
+	printLog(logLevelWarn, message, l.fields)
 }
 
 // Warnf prints out a message prefixed with `[WARN]` formatted
@@ -32,3 +55,10 @@ func (l ConsoleLogger) Warn(message string) {
 func (l ConsoleLogger) Warnf(format string, args ...interface{}) {
 	l.Warn(fmt.Sprintf(format, args...))
 }
+
+// WithFields returns a ConsoleLogger which appends the specified key/value pairs to each message
+func (l ConsoleLogger) WithFields(fields map[string]interface{}) Logger {
+	return ConsoleLogger{
+		fields: mergeLogFields(l.fields, fields),
+	}
+}
@@ -1,40 +1,153 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 var _ Logger = &DiagnosticsLogger{}
 
+// DiagnosticsLogger surfaces any warnings as Diagnostics - a new instance should be used for each operation
+// so that the warnings for one instance of a resource aren't surfaced for another
+//
+// Log messages are written to the Terraform log via tflog using the context for the current request, which
+// includes the fields set by Terraform (such as the Resource Type and Request ID) so that these can be filtered
 type DiagnosticsLogger struct {
+	// ctx is the context for the current request - when this isn't set the log messages are
+	// written using the standard library's log package instead
+	ctx context.Context
+
+	fields      map[string]interface{}
 	diagnostics diag.Diagnostics
+
+	// parent is the DiagnosticsLogger this was derived from (via WithFields), which collects the Diagnostics
+	parent *DiagnosticsLogger
+
+	// withoutDiagnostics is set for operations where the Plugin SDK can't surface Diagnostics (such as CustomizeDiff
+	// and Import) - where warnings are only logged and any attribute warnings are rejected rather than being dropped
+	withoutDiagnostics bool
+	rejectedWarnings   []string
+}
+
+func (d *DiagnosticsLogger) Trace(message string) {
+	d.write(logLevelTrace, message)
+}
+
+func (d *DiagnosticsLogger) Tracef(format string, args ...interface{}) {
+	d.write(logLevelTrace, fmt.Sprintf(format, args...))
+}
+
+func (d *DiagnosticsLogger) Debug(message string) {
+	d.write(logLevelDebug, message)
+}
+
+func (d *DiagnosticsLogger) Debugf(format string, args ...interface{}) {
+	d.write(logLevelDebug, fmt.Sprintf(format, args...))
 }
 
 func (d *DiagnosticsLogger) Info(message string) {
-	log.Printf("[INFO] %s", message)
+	d.write(logLevelInfo, message)
 }
 
 func (d *DiagnosticsLogger) Infof(format string, args ...interface{}) {
-	log.Printf("[INFO] "+format, args...)
+	d.write(logLevelInfo, fmt.Sprintf(format, args...))
 }
 
 func (d *DiagnosticsLogger) Warn(message string) {
-	d.diagnostics = append(d.diagnostics, diag.Diagnostic{
-		Severity:      diag.Warning,
-		Summary:       message,
-		Detail:        message,
-		AttributePath: nil,
+	d.append(diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  message,
 	})
 }
 
 func (d *DiagnosticsLogger) Warnf(format string, args ...interface{}) {
-	d.diagnostics = append(d.diagnostics, diag.Diagnostic{
-		Severity:      diag.Warning,
-		Summary:       fmt.Sprintf(format, args...),
-		Detail:        fmt.Sprintf(format, args...),
-		AttributePath: nil,
+	d.append(diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf(format, args...),
 	})
 }
+
+// WithFields returns a DiagnosticsLogger which includes the specified key/value pairs alongside each message,
+// any warnings raised through it are surfaced as Diagnostics for this operation
+func (d *DiagnosticsLogger) WithFields(fields map[string]interface{}) Logger {
+	parent := d.root()
+
+	return &DiagnosticsLogger{
+		ctx:    d.ctx,
+		fields: mergeLogFields(d.fields, fields),
+		parent: parent,
+	}
+}
+
+func (d *DiagnosticsLogger) write(level logLevel, message string) {
+	if d.ctx == nil {
+		printLog(level, message, d.fields)
+		return
+	}
+
+	writeLog(d.ctx, level, message, d.fields)
+}
+
+// appendAttributeWarning adds a warning raised for a specific attribute - which is rejected when Diagnostics
+// can't be surfaced for this operation, since otherwise the warning would never be shown to the user
+func (d *DiagnosticsLogger) appendAttributeWarning(path string, diagnostic diag.Diagnostic) {
+	target := d.root()
+	if !target.withoutDiagnostics {
+		d.append(diagnostic)
+		return
+	}
+
+	message := fmt.Sprintf("%s: %s", path, diagnosticMessage(diagnostic))
+	d.write(logLevelWarn, message)
+	target.rejectedWarnings = append(target.rejectedWarnings, message)
+}
+
+// rejectedWarningsError returns an error describing any attribute warnings raised during an operation
+// where Diagnostics can't be surfaced, or nil when there are none
+func (d *DiagnosticsLogger) rejectedWarningsError(operation string) error {
+	target := d.root()
+	if len(target.rejectedWarnings) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("warnings can't be surfaced by the Plugin SDK during %s, these must be raised during Create/Read/Update/Delete or returned as an error instead: %s", operation, strings.Join(target.rejectedWarnings, "; "))
+}
+
+func (d *DiagnosticsLogger) root() *DiagnosticsLogger {
+	if d.parent != nil {
+		return d.parent
+	}
+	return d
+}
+
+// append adds the Diagnostic unless an identical one already exists, since the same warning can be
+// raised more than once during an operation - for example from both the Create and Read functions
+func (d *DiagnosticsLogger) append(diagnostic diag.Diagnostic) {
+	d.write(logLevelWarn, diagnosticMessage(diagnostic))
+
+	target := d.root()
+	if target.withoutDiagnostics {
+		// the warning has been logged, which is all that's possible for this operation
+		return
+	}
+
+	for _, existing := range target.diagnostics {
+		if existing.Severity == diagnostic.Severity && existing.Summary == diagnostic.Summary && existing.Detail == diagnostic.Detail && existing.AttributePath.Equals(diagnostic.AttributePath) {
+			return
+		}
+	}
+
+	target.diagnostics = append(target.diagnostics, diagnostic)
+}
+
+// diagnosticMessage returns the message written to the log for the Diagnostic
+func diagnosticMessage(diagnostic diag.Diagnostic) string {
+	if diagnostic.Detail == "" {
+		return diagnostic.Summary
+	}
+
+	return fmt.Sprintf("%s %s", diagnostic.Summary, diagnostic.Detail)
+}
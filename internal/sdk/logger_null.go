@@ -1,10 +1,30 @@
 package sdk
 
+var _ Logger = NullLogger{}
+
 // NullLogger disregards the log output - and is intended to be used
 // when the contents of the debug logger aren't interesting
 // to reduce console output
 type NullLogger struct{}
 
+// Trace prints out a message prefixed with `[TRACE]` verbatim
+func (NullLogger) Trace(_ string) {
+}
+
+// Tracef prints out a message prefixed with `[TRACE]` formatted
+// with the specified arguments
+func (NullLogger) Tracef(_ string, _ ...interface{}) {
+}
+
+// Debug prints out a message prefixed with `[DEBUG]` verbatim
+func (NullLogger) Debug(_ string) {
+}
+
+// Debugf prints out a message prefixed with `[DEBUG]` formatted
+// with the specified arguments
+func (NullLogger) Debugf(_ string, _ ...interface{}) {
+}
+
 // Info prints out a message prefixed with `[INFO]` verbatim
 func (NullLogger) Info(_ string) {
 }
@@ -22,3 +42,8 @@ func (NullLogger) Warn(_ string) {
 // with the specified arguments
 func (NullLogger) Warnf(_ string, _ ...interface{}) {
 }
+
+// WithFields returns the NullLogger, since the log output is disregarded
+func (l NullLogger) WithFields(_ map[string]interface{}) Logger {
+	return l
+}
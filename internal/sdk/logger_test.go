@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDiagnosticsLoggerWritesToTheTerraformLog(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.TODO(), &output)
+
+	d := (&schema.Resource{}).Data(nil)
+	d.SetId("/some/id")
+
+	wrapped := diagnosticsWrapper("read", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+		logger.Debugf("retrieving %s..", d.Id())
+		logger.WithFields(map[string]interface{}{
+			"correlation_id": "abc123",
+		}).Warn("the `legacy` field is deprecated")
+
+		serializationLogger := traceLogger{logger: logger}
+		serializationLogger.Infof("Setting %q to a string", "name")
+		return nil
+	}, &DiagnosticsLogger{})
+
+	diags := wrapped(ctx, d, nil)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || diags[0].Summary != "the `legacy` field is deprecated" {
+		t.Fatalf("expected the warning to be surfaced as a Diagnostic but got %+v", diags)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding the log output: %+v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log entries but got %d: %+v", len(entries), entries)
+	}
+
+	expected := []struct {
+		level         string
+		message       string
+		correlationId interface{}
+	}{
+		{
+			level:   "debug",
+			message: "retrieving /some/id..",
+		},
+		{
+			level:         "warn",
+			message:       "the `legacy` field is deprecated",
+			correlationId: "abc123",
+		},
+		{
+			level:   "trace",
+			message: `Setting "name" to a string`,
+		},
+	}
+	for i, v := range expected {
+		entry := entries[i]
+		if entry["@level"] != v.level || entry["@message"] != v.message {
+			t.Fatalf("expected a %s entry %q but got %+v", v.level, v.message, entry)
+		}
+		if entry["operation"] != "read" || entry["resource_id"] != "/some/id" {
+			t.Fatalf("expected the operation and resource_id fields to be set but got %+v", entry)
+		}
+		if entry["correlation_id"] != v.correlationId {
+			t.Fatalf("expected the correlation_id %v but got %+v", v.correlationId, entry)
+		}
+	}
+}
+
+func TestConsoleLoggerWithFields(t *testing.T) {
+	logger := ConsoleLogger{}.WithFields(map[string]interface{}{
+		"operation": "create",
+	}).WithFields(map[string]interface{}{
+		"resource_id": "/some/id",
+	})
+
+	actual := formatLogFields(logger.(ConsoleLogger).fields)
+	if expected := ": operation=create resource_id=/some/id"; actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+}
+
+func TestSerializationDebugLoggerDoesNotLogValues(t *testing.T) {
+	type nestedModel struct {
+		SecureValue string `tfschema:"secure_value"`
+	}
+	type model struct {
+		Name                string        `tfschema:"name"`
+		EnvironmentVariable []nestedModel `tfschema:"environment_variable"`
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.TODO(), &output)
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"environment_variable": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"secure_value": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	logger := newOperationLogger(ctx, &DiagnosticsLogger{}, operationLogFields("create", ""))
+	metadata := ResourceMetaData{
+		Logger:                   logger,
+		ResourceData:             resource.TestResourceData(),
+		serializationDebugLogger: traceLogger{logger: logger},
+	}
+
+	input := model{
+		Name: "example",
+		EnvironmentVariable: []nestedModel{
+			{
+				SecureValue: "sup3r-s3cr3t",
+			},
+		},
+	}
+	if err := metadata.Encode(&input); err != nil {
+		t.Fatalf("encoding: %+v", err)
+	}
+
+	var decoded model
+	if err := metadata.Decode(&decoded); err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+	if len(decoded.EnvironmentVariable) != 1 || decoded.EnvironmentVariable[0].SecureValue != "sup3r-s3cr3t" {
+		t.Fatalf("expected the value to be decoded but got %+v", decoded)
+	}
+
+	if output.Len() == 0 {
+		t.Fatalf("expected the serialization to be logged")
+	}
+	if bytes.Contains(output.Bytes(), []byte("sup3r-s3cr3t")) {
+		t.Fatalf("expected the Sensitive value not to be logged but got: %s", output.String())
+	}
+}
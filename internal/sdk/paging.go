@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"context"
+)
+
+// AutoRestIterator is implemented by the Iterators returned from the `ListComplete` functions generated by
+// AutoRest (for example `keyvault.SecretListResultIterator`), which page through the results as required
+type AutoRestIterator[T any] interface {
+	NotDone() bool
+	Value() T
+	NextWithContext(ctx context.Context) error
+}
+
+// ListFromAutoRestIterator pages through all of the results from the AutoRest Iterator (which must be
+// passed by reference), mapping each item into the Model - where the mapping function can return nil
+// to skip an item (for example when it doesn't match a filter).
+//
+// When maxResults is greater than zero, iteration stops once this many items have been returned.
+func ListFromAutoRestIterator[T any, M any](ctx context.Context, iterator AutoRestIterator[T], maxResults int, mapFunc func(item T) (*M, error)) ([]M, error) {
+	out := make([]M, 0)
+	for iterator.NotDone() {
+		if maxResults > 0 && len(out) >= maxResults {
+			break
+		}
+
+		v, err := mapFunc(iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			out = append(out, *v)
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// ListFromItems maps the items returned from the `ListComplete` (or `ListCompleteMatchingPredicate`) functions
+// in go-azure-sdk (which have already paged through the results) into the Model, in the same manner as
+// ListFromAutoRestIterator - returning at most maxResults items when this is greater than zero.
+//
+// NOTE: unlike ListFromAutoRestIterator, maxResults doesn't reduce the number of requests made - since the
+// `ListComplete` functions retrieve every page before returning. Where the number of results can be large,
+// the `Top` field within the `ListOperationOptions` (where supported by the API) should be used instead.
+func ListFromItems[T any, M any](items []T, maxResults int, mapFunc func(item T) (*M, error)) ([]M, error) {
+	out := make([]M, 0)
+	for _, item := range items {
+		if maxResults > 0 && len(out) >= maxResults {
+			break
+		}
+
+		v, err := mapFunc(item)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			out = append(out, *v)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// fakeIterator pages through the values in the same manner as an AutoRest Iterator
+type fakeIterator struct {
+	pages [][]string
+	page  int
+	index int
+	calls int
+}
+
+func (i *fakeIterator) NotDone() bool {
+	return i.page < len(i.pages) && i.index < len(i.pages[i.page])
+}
+
+func (i *fakeIterator) Value() string {
+	return i.pages[i.page][i.index]
+}
+
+func (i *fakeIterator) NextWithContext(_ context.Context) error {
+	i.calls++
+	i.index++
+	if i.index >= len(i.pages[i.page]) {
+		i.page++
+		i.index = 0
+	}
+	return nil
+}
+
+func TestListFromAutoRestIterator(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	mapFunc := func(v string) (*item, error) {
+		if v == "skipped" {
+			return nil, nil
+		}
+		return &item{Name: v}, nil
+	}
+
+	testData := []struct {
+		maxResults    int
+		expected      []string
+		expectedCalls int
+	}{
+		{
+			maxResults:    0,
+			expected:      []string{"first", "second", "third", "fourth"},
+			expectedCalls: 5,
+		},
+		{
+			maxResults:    3,
+			expected:      []string{"first", "second", "third"},
+			expectedCalls: 4,
+		},
+		{
+			maxResults:    10,
+			expected:      []string{"first", "second", "third", "fourth"},
+			expectedCalls: 5,
+		},
+	}
+	for _, v := range testData {
+		iterator := &fakeIterator{
+			pages: [][]string{
+				{"first", "skipped"},
+				{"second", "third"},
+				{"fourth"},
+			},
+		}
+		actual, err := ListFromAutoRestIterator[string](context.TODO(), iterator, v.maxResults, mapFunc)
+		if err != nil {
+			t.Fatalf("listing: %+v", err)
+		}
+		if len(actual) != len(v.expected) {
+			t.Fatalf("expected %d items but got %d: %+v", len(v.expected), len(actual), actual)
+		}
+		for i, name := range v.expected {
+			if actual[i].Name != name {
+				t.Fatalf("expected item %d to be %q but got %q", i, name, actual[i].Name)
+			}
+		}
+		if iterator.calls != v.expectedCalls {
+			t.Fatalf("expected %d calls to NextWithContext but got %d", v.expectedCalls, iterator.calls)
+		}
+	}
+
+	iterator := &fakeIterator{
+		pages: [][]string{{"first"}},
+	}
+	_, err := ListFromAutoRestIterator[string](context.TODO(), iterator, 0, func(v string) (*item, error) {
+		return nil, fmt.Errorf("boom")
+	})
+	if err == nil {
+		t.Fatalf("expected an error from the mapping function")
+	}
+}
+
+func TestListFromItems(t *testing.T) {
+	actual, err := ListFromItems([]int{1, 2, 3, 4, 5}, 2, func(v int) (*string, error) {
+		if v%2 == 0 {
+			return nil, nil
+		}
+		out := fmt.Sprintf("item-%d", v)
+		return &out, nil
+	})
+	if err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+	if len(actual) != 2 || actual[0] != "item-1" || actual[1] != "item-3" {
+		t.Fatalf("expected [item-1 item-3] but got %+v", actual)
+	}
+}
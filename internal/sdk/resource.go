@@ -24,9 +24,8 @@ type resourceWithPluginSdkSchema interface {
 
 type resourceBase interface {
 	// resourceWithPluginSdkSchema ensure that the Arguments and Attributes are sourced
-	// from Plugin SDKv2 for now - longer term we'll likely introduce a `Typed Schema`
-	// which will cross-compile down to both the Plugin SDKv2 and Plugin Framework, but
-	// that's a story for another day.
+	// from Plugin SDKv2 for now - these can be compiled (via the TypedSchema) down to the
+	// Plugin Framework, however Resources/Data Sources are only served using Plugin SDKv2.
 	resourceWithPluginSdkSchema
 
 	// ModelObject is an instance of the object the Schema is decoded/encoded into
@@ -73,6 +72,18 @@ type Resource interface {
 	IDValidationFunc() pluginsdk.SchemaValidateFunc
 }
 
+// DataSourceWithNotFoundBehaviour is an optional interface
+//
+// Data Sources implementing this interface gain a `not_found_behaviour` argument (defaulting to the value returned
+// from DefaultNotFoundBehaviour) and an `exists` attribute - allowing users to opt into an empty result, rather than
+// an error, when the object doesn't exist. The Read function should return `metadata.NotFound(id)` in this case.
+type DataSourceWithNotFoundBehaviour interface {
+	DataSource
+
+	// DefaultNotFoundBehaviour returns the default value for the `not_found_behaviour` argument
+	DefaultNotFoundBehaviour() DataSourceNotFoundBehaviour
+}
+
 type ResourceWithStateMigration interface {
 	Resource
 	StateUpgraders() StateUpgradeData
@@ -83,8 +94,6 @@ type StateUpgradeData struct {
 	Upgraders     map[int]pluginsdk.StateUpgrade
 }
 
-// TODO: a generic state migration for updating ID's
-
 type ResourceWithCustomImporter interface {
 	Resource
 
@@ -140,6 +149,39 @@ type ResourceWithCustomizeDiff interface {
 	CustomizeDiff() ResourceFunc
 }
 
+// ResourceWithConfigValidation is an optional interface
+//
+// Resources implementing this interface have their Configuration validated at plan time, which allows
+// rules spanning multiple fields (for example the bounds of a `rotation_policy`) to be validated using the
+// Model rather than the ResourceDiff - the Model can be decoded using `metadata.DecodeDiff`, and errors
+// for a specific field should be returned as an AttributeError (e.g. using NewAttributeErrorForField).
+//
+// NOTE: values which aren't known until apply are decoded as their zero value - so `metadata.NewValueKnown` should
+// be used to skip validating these, and this runs prior to CustomizeDiff.
+//
+// NOTE: the Plugin SDK can only return an error (rather than Diagnostics) at plan time, so the AttributePath of an
+// AttributeError isn't surfaced to Terraform - instead the path is included at the start of the error message.
+type ResourceWithConfigValidation interface {
+	Resource
+
+	// ValidateConfig returns a ResourceFunc which validates the Configuration for this Resource
+	ValidateConfig() ResourceFunc
+}
+
+// ResourceWithEventualConsistency is an optional interface for Resources which may not be returned by the
+// API immediately after being created or updated - for example Key Vault data plane items, where the
+// object may not yet have replicated.
+//
+// When implemented, the Read which follows a Create or Update is retried (with backoff) whilst the
+// Resource is marked as gone, until it's returned, the Consistency Window elapses or the operation times out.
+type ResourceWithEventualConsistency interface {
+	Resource
+
+	// ConsistencyWindow returns the maximum duration that the Resource may not be returned by the API
+	// after being created or updated
+	ConsistencyWindow() time.Duration
+}
+
 // ResourceRunFunc is the function which can be run
 // ctx provides a Context instance with the user-provided timeout
 // metadata is a reference to an object containing the Client, ResourceData and a Logger
@@ -178,7 +220,7 @@ type ResourceMetaData struct {
 
 // MarkAsGone marks this resource as removed in the Remote API, so this is no longer available
 func (rmd ResourceMetaData) MarkAsGone(idFormatter resourceids.Id) error {
-	rmd.Logger.Infof("[DEBUG] %s was not found - removing from state", idFormatter)
+	rmd.Logger.Debugf("%s was not found - removing from state", idFormatter)
 	rmd.ResourceData.SetId("")
 	return nil
 }
@@ -1,12 +1,20 @@
 package sdk
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var (
+	// timeType and rawMessageType are stored as strings in the Terraform Schema, rather than by their kind
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
 // Decode will decode the Terraform Schema into the specified object
 // NOTE: this object must be passed by value - and must contain `tfschema`
 // struct tags for all fields
@@ -19,6 +27,14 @@ import (
 //
 // var person Person
 // if err := metadata.Decode(&person); err != nil { .. }
+//
+// In addition to primitives (and named types based on them, such as enums), slices, maps
+// and slices of nested structs - `time.Time` (as RFC3339) and `json.RawMessage` fields are
+// stored as strings, and top-level pointer fields are left nil when the value isn't set.
+//
+// NOTE: the Plugin SDK returns the zero value for unset fields within nested blocks, so
+// pointer fields within nested structs are always assigned (to the zero value when unset)
+// and can't be used to distinguish unset values from zero values.
 func (rmd ResourceMetaData) Decode(input interface{}) error {
 	if rmd.ResourceData == nil {
 		return fmt.Errorf("ResourceData was nil")
@@ -51,7 +67,7 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	objType := reflect.TypeOf(input).Elem()
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
-		debugLogger.Infof("Field", field)
+		debugLogger.Infof("Field: %q (%s)", field.Name, field.Type)
 
 		if val, exists := field.Tag.Lookup("tfschema"); exists {
 			tfschemaValue, valExists := stateRetriever.GetOkExists(val)
@@ -59,12 +75,12 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 				continue
 			}
 
-			debugLogger.Infof("TFSchemaValue: ", tfschemaValue)
-			debugLogger.Infof("Input Type: ", reflect.ValueOf(input).Elem().Field(i).Type())
+			// only the types are logged, since the values may be Sensitive
+			debugLogger.Infof("TFSchemaValue Type: %T", tfschemaValue)
+			debugLogger.Infof("Input Type: %+v", reflect.ValueOf(input).Elem().Field(i).Type())
 
-			fieldName := reflect.ValueOf(input).Elem().Field(i).String()
-			if err := setValue(input, tfschemaValue, i, fieldName, debugLogger); err != nil {
-				return fmt.Errorf("while setting value %+v of model field %q: %+v", tfschemaValue, fieldName, err)
+			if err := setValue(input, tfschemaValue, i, val, debugLogger); err != nil {
+				return fmt.Errorf("while setting value of model field %q: %+v", val, err)
 			}
 		}
 	}
@@ -72,12 +88,13 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 }
 
 func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
-	debugLogger.Infof("setting list value for %q..", fieldName)
+	debugLogger.Infof("setting value for %q..", fieldName)
 	defer func() {
 		if r := recover(); r != nil {
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
 			out, ok := r.(error)
 			if !ok {
+				errOut = fmt.Errorf("setting value for %q: %+v", fieldName, r)
 				return
 			}
 
@@ -85,135 +102,202 @@ func setValue(input, tfschemaValue interface{}, index int, fieldName string, deb
 		}
 	}()
 
-	if v, ok := tfschemaValue.(string); ok {
-		debugLogger.Infof("[String] Decode %+v", v)
-		debugLogger.Infof("Input %+v", reflect.ValueOf(input))
-		debugLogger.Infof("Input Elem %+v", reflect.ValueOf(input).Elem())
-		reflect.ValueOf(input).Elem().Field(index).SetString(v)
+	return decodeValue(reflect.ValueOf(input).Elem().Field(index), tfschemaValue, fieldName, debugLogger)
+}
+
+// decodeValue sets the value from the Terraform Schema into the target, based on the type of the target
+func decodeValue(target reflect.Value, tfschemaValue interface{}, fieldName string, debugLogger Logger) error {
+	if tfschemaValue == nil {
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	switch target.Type() {
+	case timeType:
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for the time %q but got %T", fieldName, tfschemaValue)
+		}
+		if v == "" {
+			return nil
+		}
+
+		debugLogger.Infof("[TIME] Decode %q", fieldName)
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("parsing %q as an RFC3339 time for %q: %+v", v, fieldName, err)
+		}
+		target.Set(reflect.ValueOf(t))
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(int32); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(int64(v))
+	case rawMessageType:
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for the JSON %q but got %T", fieldName, tfschemaValue)
+		}
+		if v == "" {
+			return nil
+		}
+
+		debugLogger.Infof("[JSON] Decode %q", fieldName)
+		if !json.Valid([]byte(v)) {
+			return fmt.Errorf("the value for %q is not valid JSON", fieldName)
+		}
+		target.Set(reflect.ValueOf(json.RawMessage(v)))
 		return nil
 	}
 
-	if v, ok := tfschemaValue.(int64); ok {
-		debugLogger.Infof("[INT] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetInt(v)
+	switch target.Kind() {
+	case reflect.Ptr:
+		// pointers are only assigned when a value exists, so that unset values can be distinguished from zero values
+		elem := reflect.New(target.Type().Elem())
+		if err := decodeValue(elem.Elem(), tfschemaValue, fieldName, debugLogger); err != nil {
+			return err
+		}
+		target.Set(elem)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(float64); ok {
-		debugLogger.Infof("[Float] Decode %+v", v)
-		reflect.ValueOf(input).Elem().Field(index).SetFloat(v)
+	case reflect.String:
+		v, ok := tfschemaValue.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[String] Decode %q", fieldName)
+		target.SetString(v)
 		return nil
-	}
 
-	// Doesn't work for empty bools?
-	if v, ok := tfschemaValue.(bool); ok {
-		debugLogger.Infof("[BOOL] Decode %+v", v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		switch i := tfschemaValue.(type) {
+		case int:
+			v = int64(i)
+		case int32:
+			v = int64(i)
+		case int64:
+			v = i
+		default:
+			return fmt.Errorf("expected an int for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[INT] Decode %q", fieldName)
+		target.SetInt(v)
+		return nil
 
-		reflect.ValueOf(input).Elem().Field(index).SetBool(v)
+	case reflect.Float32, reflect.Float64:
+		v, ok := tfschemaValue.(float64)
+		if !ok {
+			return fmt.Errorf("expected a float for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[Float] Decode %q", fieldName)
+		target.SetFloat(v)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.(*schema.Set); ok {
-		return setListValue(input, index, fieldName, v.List(), debugLogger)
-	}
+	case reflect.Bool:
+		v, ok := tfschemaValue.(bool)
+		if !ok {
+			return fmt.Errorf("expected a bool for %q but got %T", fieldName, tfschemaValue)
+		}
+		debugLogger.Infof("[BOOL] Decode %q", fieldName)
+		target.SetBool(v)
+		return nil
 
-	if mapConfig, ok := tfschemaValue.(map[string]interface{}); ok {
-		mapOutput := reflect.MakeMap(reflect.ValueOf(input).Elem().Field(index).Type())
-		for key, val := range mapConfig {
-			mapOutput.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
+	case reflect.Interface:
+		target.Set(reflect.ValueOf(tfschemaValue))
+		return nil
+
+	case reflect.Map:
+		mapConfig, ok := tfschemaValue.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected a map for %q but got %T", fieldName, tfschemaValue)
+		}
+		if target.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s for %q", target.Type().Key(), fieldName)
 		}
 
-		reflect.ValueOf(input).Elem().Field(index).Set(mapOutput)
+		mapOutput := reflect.MakeMap(target.Type())
+		for key, val := range mapConfig {
+			elem := reflect.New(target.Type().Elem()).Elem()
+			if err := decodeValue(elem, val, fmt.Sprintf("%s.%s", fieldName, key), debugLogger); err != nil {
+				return err
+			}
+			mapOutput.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), elem)
+		}
+		target.Set(mapOutput)
 		return nil
-	}
 
-	if v, ok := tfschemaValue.([]interface{}); ok {
-		return setListValue(input, index, fieldName, v, debugLogger)
+	case reflect.Slice:
+		switch v := tfschemaValue.(type) {
+		case *schema.Set:
+			return setListValue(target, fieldName, v.List(), debugLogger)
+		case []interface{}:
+			return setListValue(target, fieldName, v, debugLogger)
+		}
+
+		// typed slices aren't returned by the Plugin SDK, however are supported for completeness
+		raw := reflect.ValueOf(tfschemaValue)
+		if raw.Kind() != reflect.Slice {
+			return fmt.Errorf("expected a list or set for %q but got %T", fieldName, tfschemaValue)
+		}
+		if raw.Len() == 0 {
+			return nil
+		}
+		items := make([]interface{}, raw.Len())
+		for i := 0; i < raw.Len(); i++ {
+			items[i] = raw.Index(i).Interface()
+		}
+		return setListValue(target, fieldName, items, debugLogger)
 	}
 
-	return nil
+	return fmt.Errorf("unsupported type %s for %q", target.Type(), fieldName)
 }
 
-func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
-	switch fieldType := reflect.ValueOf(input).Elem().Field(index).Type(); fieldType {
-	case reflect.TypeOf([]string{}):
-		stringSlice := reflect.MakeSlice(reflect.TypeOf([]string{}), len(v), len(v))
-		for i, stringVal := range v {
-			stringSlice.Index(i).SetString(stringVal.(string))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(stringSlice)
-
-	case reflect.TypeOf([]int{}):
-		iSlice := reflect.MakeSlice(reflect.TypeOf([]int{}), len(v), len(v))
-		for i, iVal := range v {
-			iSlice.Index(i).SetInt(int64(iVal.(int)))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(iSlice)
-
-	case reflect.TypeOf([]float64{}):
-		fSlice := reflect.MakeSlice(reflect.TypeOf([]float64{}), len(v), len(v))
-		for i, fVal := range v {
-			fSlice.Index(i).SetFloat(fVal.(float64))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(fSlice)
-
-	case reflect.TypeOf([]bool{}):
-		bSlice := reflect.MakeSlice(reflect.TypeOf([]bool{}), len(v), len(v))
-		for i, bVal := range v {
-			bSlice.Index(i).SetBool(bVal.(bool))
-		}
-		reflect.ValueOf(input).Elem().Field(index).Set(bSlice)
-
-	default:
-		valueToSet := reflect.MakeSlice(reflect.ValueOf(input).Elem().Field(index).Type(), 0, 0)
-		debugLogger.Infof("List Type", valueToSet.Type())
-
-		for _, mapVal := range v {
-			if test, ok := mapVal.(map[string]interface{}); ok && test != nil {
-				elem := reflect.New(fieldType.Elem())
-				debugLogger.Infof("element ", elem)
-				for j := 0; j < elem.Type().Elem().NumField(); j++ {
-					nestedField := elem.Type().Elem().Field(j)
-					debugLogger.Infof("nestedField ", nestedField)
-
-					if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
-						nestedTFSchemaValue := test[val]
-						if err := setValue(elem.Interface(), nestedTFSchemaValue, j, fieldName, debugLogger); err != nil {
-							return err
-						}
-					}
-				}
+func setListValue(target reflect.Value, fieldName string, v []interface{}, debugLogger Logger) error {
+	elemType := target.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
 
-				if !elem.CanSet() {
-					elem = elem.Elem()
-				}
+	valueToSet := reflect.MakeSlice(target.Type(), 0, len(v))
+	debugLogger.Infof("List Type: %+v", valueToSet.Type())
 
-				if valueToSet.Kind() == reflect.Ptr {
-					valueToSet.Elem().Set(reflect.Append(valueToSet.Elem(), elem))
-				} else {
-					valueToSet = reflect.Append(valueToSet, elem)
-				}
+	for i, item := range v {
+		itemName := fmt.Sprintf("%s.%d", fieldName, i)
 
-				debugLogger.Infof("value to set type after changes", valueToSet.Type())
+		if structType.Kind() != reflect.Struct || structType == timeType {
+			elem := reflect.New(elemType).Elem()
+			if err := decodeValue(elem, item, itemName, debugLogger); err != nil {
+				return err
 			}
+			valueToSet = reflect.Append(valueToSet, elem)
+			continue
+		}
+
+		nestedValues, ok := item.(map[string]interface{})
+		if !ok || nestedValues == nil {
+			continue
+		}
+
+		elem := reflect.New(structType)
+		debugLogger.Infof("element: %s", elem.Type())
+		for j := 0; j < structType.NumField(); j++ {
+			nestedField := structType.Field(j)
+			debugLogger.Infof("nestedField: %q (%s)", nestedField.Name, nestedField.Type)
+
+			if val, exists := nestedField.Tag.Lookup("tfschema"); exists {
+				nestedFieldName := fmt.Sprintf("%s.%s", itemName, val)
+				if err := decodeValue(elem.Elem().Field(j), nestedValues[val], nestedFieldName, debugLogger); err != nil {
+					return err
+				}
+			}
+		}
+
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
 		}
+		valueToSet = reflect.Append(valueToSet, elem)
 
-		valueToSet = reflect.Indirect(valueToSet)
-		fieldToSet := reflect.ValueOf(input).Elem().Field(index)
-		fieldToSet.Set(reflect.Indirect(valueToSet))
+		debugLogger.Infof("value to set type after changes: %+v", valueToSet.Type())
 	}
 
+	target.Set(valueToSet)
 	return nil
 }
@@ -1,8 +1,13 @@
 package sdk
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
 type decodeTestData struct {
@@ -698,6 +703,171 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestDecode_RicherTypes(t *testing.T) {
+	type Colour string
+	type Inner struct {
+		Value string `tfschema:"value"`
+	}
+	type Type struct {
+		Created       time.Time         `tfschema:"created"`
+		Expires       time.Time         `tfschema:"expires"`
+		Description   *string           `tfschema:"description"`
+		Enabled       *bool             `tfschema:"enabled"`
+		Unset         *int              `tfschema:"unset"`
+		Colour        Colour            `tfschema:"colour"`
+		Colours       []Colour          `tfschema:"colours"`
+		ColoursByName map[string]Colour `tfschema:"colours_by_name"`
+		Counts        map[string]int    `tfschema:"counts"`
+		Flags         map[string]bool   `tfschema:"flags"`
+		Inners        []Inner           `tfschema:"inners"`
+		Settings      json.RawMessage   `tfschema:"settings"`
+	}
+	created := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	innerSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"created":     "2021-02-03T04:05:06Z",
+			"expires":     "",
+			"description": "",
+			"enabled":     false,
+			"colour":      "red",
+			"colours":     []interface{}{"green", "blue"},
+			"colours_by_name": map[string]interface{}{
+				"sky": "blue",
+			},
+			"counts": map[string]interface{}{
+				"hello": 1,
+			},
+			"flags": map[string]interface{}{
+				"enabled": true,
+			},
+			"inners": schema.NewSet(schema.HashResource(innerSchema), []interface{}{
+				map[string]interface{}{
+					"value": "first",
+				},
+			}),
+			"settings": `{"hello":"world"}`,
+		},
+		Input: &Type{},
+		Expected: &Type{
+			Created:     created,
+			Description: utils.String(""),
+			Enabled:     utils.Bool(false),
+			Colour:      "red",
+			Colours:     []Colour{"green", "blue"},
+			ColoursByName: map[string]Colour{
+				"sky": "blue",
+			},
+			Counts: map[string]int{
+				"hello": 1,
+			},
+			Flags: map[string]bool{
+				"enabled": true,
+			},
+			Inners: []Inner{
+				{
+					Value: "first",
+				},
+			},
+			Settings: json.RawMessage(`{"hello":"world"}`),
+		},
+	}.test(t)
+}
+
+func TestDecode_NestedPointersAreAlwaysAssigned(t *testing.T) {
+	// the Plugin SDK returns the zero value for unset fields within nested blocks, so unlike
+	// top-level fields, pointers within nested blocks can't distinguish unset from zero values
+	type Inner struct {
+		Name  *string `tfschema:"name"`
+		Count *int    `tfschema:"count"`
+	}
+	type Type struct {
+		Name   *string `tfschema:"name"`
+		Inners []Inner `tfschema:"inner"`
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"inner": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"count": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"inner": []interface{}{
+			map[string]interface{}{
+				"count": 5,
+			},
+		},
+	})
+
+	var actual Type
+	if err := decodeReflectedType(&actual, d, NullLogger{}); err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+
+	if actual.Name != nil {
+		t.Fatalf("expected the top-level pointer to be nil but got %q", *actual.Name)
+	}
+	if len(actual.Inners) != 1 {
+		t.Fatalf("expected 1 nested item but got %d", len(actual.Inners))
+	}
+	if v := actual.Inners[0].Name; v == nil || *v != "" {
+		t.Fatalf("expected the unset nested pointer to be assigned the zero value but got %+v", v)
+	}
+	if v := actual.Inners[0].Count; v == nil || *v != 5 {
+		t.Fatalf("expected the nested pointer to be assigned 5 but got %+v", v)
+	}
+}
+
+func TestDecode_InvalidTime(t *testing.T) {
+	type Type struct {
+		Created time.Time `tfschema:"created"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"created": "yesterday",
+		},
+		Input:       &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestDecode_UnsupportedType(t *testing.T) {
+	type Type struct {
+		Channel chan string `tfschema:"channel"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"channel": "hello",
+		},
+		Input:       &Type{},
+		ExpectError: true,
+	}.test(t)
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
@@ -1,13 +1,18 @@
 package sdk
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // Encode will encode the specified object into the Terraform State
 // NOTE: this requires that the object passed in is a pointer and
-// all fields contain `tfschema` struct tags
+// all fields contain `tfschema` struct tags - see Decode for the supported types,
+// notably nil pointers are encoded as null and JSON is normalised
 func (rmd ResourceMetaData) Encode(input interface{}) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
@@ -16,8 +21,7 @@ func (rmd ResourceMetaData) Encode(input interface{}) error {
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
 
-	fieldName := reflect.ValueOf(input).Elem().String()
-	serialized, err := recurse(objType, objVal, fieldName, rmd.serializationDebugLogger)
+	serialized, err := recurse(objType, objVal, "", rmd.serializationDebugLogger)
 	if err != nil {
 		return err
 	}
@@ -37,6 +41,7 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 			debugLogger.Warnf("error setting value for %q: %+v", fieldName, r)
 			out, ok := r.(error)
 			if !ok {
+				errOut = fmt.Errorf("serializing %q: %+v", fieldName, r)
 				return
 			}
 
@@ -49,93 +54,211 @@ func recurse(objType reflect.Type, objVal reflect.Value, fieldName string, debug
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
 		if tfschemaTag, exists := field.Tag.Lookup("tfschema"); exists {
-			switch field.Type.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				iv := fieldVal.Int()
-				debugLogger.Infof("Setting %q to %d", tfschemaTag, iv)
-				output[tfschemaTag] = iv
-
-			case reflect.Float32, reflect.Float64:
-				fv := fieldVal.Float()
-				debugLogger.Infof("Setting %q to %f", tfschemaTag, fv)
-				output[tfschemaTag] = fv
-
-			case reflect.String:
-				sv := fieldVal.String()
-				debugLogger.Infof("Setting %q to %q", tfschemaTag, sv)
-				output[tfschemaTag] = sv
-
-			case reflect.Bool:
-				bv := fieldVal.Bool()
-				debugLogger.Infof("Setting %q to %t", tfschemaTag, bv)
-				output[tfschemaTag] = bv
-
-			case reflect.Map:
-				iter := fieldVal.MapRange()
-				attr := make(map[string]interface{})
-				for iter.Next() {
-					attr[iter.Key().String()] = iter.Value().Interface()
-				}
-				output[tfschemaTag] = attr
-
-			case reflect.Slice:
-				sv := fieldVal.Slice(0, fieldVal.Len())
-				attr := make([]interface{}, sv.Len())
-				switch sv.Type() {
-				case reflect.TypeOf([]string{}):
-					debugLogger.Infof("Setting %q to []string", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]string, 0)
-					}
-
-				case reflect.TypeOf([]int{}):
-					debugLogger.Infof("Setting %q to []int", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]int, 0)
-					}
-
-				case reflect.TypeOf([]float64{}):
-					debugLogger.Infof("Setting %q to []float64", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]float64, 0)
-					}
-
-				case reflect.TypeOf([]bool{}):
-					debugLogger.Infof("Setting %q to []bool", tfschemaTag)
-					if sv.Len() > 0 {
-						output[tfschemaTag] = sv.Interface()
-					} else {
-						output[tfschemaTag] = make([]bool, 0)
-					}
-
-				default:
-					for i := 0; i < sv.Len(); i++ {
-						debugLogger.Infof("[SLICE] Index %d is %q", i, sv.Index(i).Interface())
-						debugLogger.Infof("[SLICE] Type %+v", sv.Type())
-						nestedType := sv.Index(i).Type()
-						nestedValue := sv.Index(i)
-
-						fieldName := field.Name
-						serialized, err := recurse(nestedType, nestedValue, fieldName, debugLogger)
-						if err != nil {
-							return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), err)
-						}
-						attr[i] = serialized
-					}
-					debugLogger.Infof("[SLICE] Setting %q to %+v", tfschemaTag, attr)
-					output[tfschemaTag] = attr
-				}
-			default:
-				return output, fmt.Errorf("unknown type %+v for key %q", field.Type.Kind(), tfschemaTag)
+			path := tfschemaTag
+			if fieldName != "" {
+				path = fmt.Sprintf("%s.%s", fieldName, tfschemaTag)
 			}
+
+			value, err := encodeValue(fieldVal, path, debugLogger)
+			if err != nil {
+				return output, err
+			}
+			output[tfschemaTag] = value
 		}
 	}
 
 	return output, nil
 }
+
+// normaliseJSON round-trips the JSON to normalise it, since the keys within objects are sorted when marshalling.
+// Numbers are retained as-is (rather than converted to a float64, losing precision) and HTML characters
+// (`<`, `>` and `&`) aren't escaped, so that the normalised value matches the value in the configuration
+func normaliseJSON(raw json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "", fmt.Errorf("the value is not valid JSON: %+v", err)
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("the value is not valid JSON: unexpected data after the top-level value")
+	}
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+
+	// the Encoder terminates each value with a newline
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// encodeValue converts the value into the type used by the Terraform Schema, based on the type of the value
+func encodeValue(fieldVal reflect.Value, fieldName string, debugLogger Logger) (interface{}, error) {
+	switch fieldVal.Type() {
+	case timeType:
+		t := fieldVal.Interface().(time.Time)
+		debugLogger.Infof("Setting %q to a time", fieldName)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(time.RFC3339), nil
+
+	case rawMessageType:
+		raw := fieldVal.Interface().(json.RawMessage)
+		debugLogger.Infof("Setting %q to JSON", fieldName)
+		if len(raw) == 0 {
+			return "", nil
+		}
+
+		normalised, err := normaliseJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("normalising the JSON for %q: %+v", fieldName, err)
+		}
+		return normalised, nil
+	}
+
+	switch fieldVal.Kind() {
+	case reflect.Ptr:
+		if fieldVal.IsNil() {
+			debugLogger.Infof("Setting %q to nil", fieldName)
+			return nil, nil
+		}
+		return encodeValue(fieldVal.Elem(), fieldName, debugLogger)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		iv := fieldVal.Int()
+		debugLogger.Infof("Setting %q to an int", fieldName)
+		return iv, nil
+
+	case reflect.Float32, reflect.Float64:
+		fv := fieldVal.Float()
+		debugLogger.Infof("Setting %q to a float", fieldName)
+		return fv, nil
+
+	case reflect.String:
+		sv := fieldVal.String()
+		debugLogger.Infof("Setting %q to a string", fieldName)
+		return sv, nil
+
+	case reflect.Bool:
+		bv := fieldVal.Bool()
+		debugLogger.Infof("Setting %q to a bool", fieldName)
+		return bv, nil
+
+	case reflect.Map:
+		if fieldVal.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s for %q", fieldVal.Type().Key(), fieldName)
+		}
+
+		attr := make(map[string]interface{})
+		iter := fieldVal.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			value, err := encodeMapValue(iter.Value(), fmt.Sprintf("%s.%s", fieldName, key))
+			if err != nil {
+				return nil, err
+			}
+			attr[key] = value
+		}
+		return attr, nil
+
+	case reflect.Slice:
+		return encodeSliceValue(fieldVal, fieldName, debugLogger)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s for %q", fieldVal.Type(), fieldName)
+}
+
+// encodeMapValue returns the underlying primitive value, since the Plugin SDK doesn't support named types within maps
+func encodeMapValue(value reflect.Value, fieldName string) (interface{}, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Interface:
+		return value.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported map value type %s for %q", value.Type(), fieldName)
+}
+
+func encodeSliceValue(fieldVal reflect.Value, fieldName string, debugLogger Logger) (interface{}, error) {
+	sv := fieldVal.Slice(0, fieldVal.Len())
+	elemType := sv.Type().Elem()
+
+	// slices of primitives (including named types, such as enums) are converted to a slice of the underlying type
+	switch elemType.Kind() {
+	case reflect.String:
+		debugLogger.Infof("Setting %q to []string", fieldName)
+		out := make([]string, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			out[i] = sv.Index(i).String()
+		}
+		return out, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		debugLogger.Infof("Setting %q to []int", fieldName)
+		out := make([]int, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			out[i] = int(sv.Index(i).Int())
+		}
+		return out, nil
+
+	case reflect.Float32, reflect.Float64:
+		debugLogger.Infof("Setting %q to []float64", fieldName)
+		out := make([]float64, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			out[i] = sv.Index(i).Float()
+		}
+		return out, nil
+
+	case reflect.Bool:
+		debugLogger.Infof("Setting %q to []bool", fieldName)
+		out := make([]bool, sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			out[i] = sv.Index(i).Bool()
+		}
+		return out, nil
+	}
+
+	attr := make([]interface{}, sv.Len())
+	for i := 0; i < sv.Len(); i++ {
+		debugLogger.Infof("[SLICE] Index %d of %q", i, fieldName)
+		debugLogger.Infof("[SLICE] Type %+v", sv.Type())
+		nestedValue := sv.Index(i)
+		nestedName := fmt.Sprintf("%s.%d", fieldName, i)
+
+		if nestedValue.Kind() == reflect.Ptr {
+			if nestedValue.IsNil() {
+				return nil, fmt.Errorf("item %q is nil", nestedName)
+			}
+			nestedValue = nestedValue.Elem()
+		}
+
+		if nestedValue.Kind() != reflect.Struct || nestedValue.Type() == timeType {
+			value, err := encodeValue(nestedValue, nestedName, debugLogger)
+			if err != nil {
+				return nil, err
+			}
+			attr[i] = value
+			continue
+		}
+
+		serialized, err := recurse(nestedValue.Type(), nestedValue, nestedName, debugLogger)
+		if err != nil {
+			return nil, fmt.Errorf("serializing nested object %q: %+v", sv.Type(), err)
+		}
+		attr[i] = serialized
+	}
+	debugLogger.Infof("[SLICE] Setting %q to %d items", fieldName, len(attr))
+	return attr, nil
+}
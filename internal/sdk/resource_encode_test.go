@@ -1,10 +1,13 @@
 package sdk
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
 type encodeTestData struct {
@@ -647,13 +650,156 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleItems(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_RicherTypes(t *testing.T) {
+	type Colour string
+	type Inner struct {
+		Value   *string   `tfschema:"value"`
+		Created time.Time `tfschema:"created"`
+	}
+	type Type struct {
+		Created       time.Time         `tfschema:"created"`
+		Expires       time.Time         `tfschema:"expires"`
+		Description   *string           `tfschema:"description"`
+		Unset         *int              `tfschema:"unset"`
+		Colour        Colour            `tfschema:"colour"`
+		Colours       []Colour          `tfschema:"colours"`
+		ColoursByName map[string]Colour `tfschema:"colours_by_name"`
+		Counts        map[string]int    `tfschema:"counts"`
+		Inners        []Inner           `tfschema:"inners"`
+		Settings      json.RawMessage   `tfschema:"settings"`
+		EmptySettings json.RawMessage   `tfschema:"empty_settings"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			Created:     time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC),
+			Description: utils.String(""),
+			Colour:      "red",
+			Colours:     []Colour{"green", "blue"},
+			ColoursByName: map[string]Colour{
+				"sky": "blue",
+			},
+			Counts: map[string]int{
+				"hello": 1,
+			},
+			Inners: []Inner{
+				{
+					Value: utils.String("first"),
+				},
+				{},
+			},
+			Settings: json.RawMessage(`{ "b": [1, 2], "a": "hello" }`),
+		},
+		Expected: map[string]interface{}{
+			"created":     "2021-02-03T04:05:06Z",
+			"expires":     "",
+			"description": "",
+			"unset":       nil,
+			"colour":      "red",
+			"colours":     []string{"green", "blue"},
+			"colours_by_name": map[string]interface{}{
+				"sky": "blue",
+			},
+			"counts": map[string]interface{}{
+				"hello": 1,
+			},
+			"inners": []interface{}{
+				map[string]interface{}{
+					"value":   "first",
+					"created": "",
+				},
+				map[string]interface{}{
+					"value":   nil,
+					"created": "",
+				},
+			},
+			"settings":       `{"a":"hello","b":[1,2]}`,
+			"empty_settings": "",
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_JSONIsNotEscaped(t *testing.T) {
+	type Type struct {
+		Settings json.RawMessage `tfschema:"settings"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			Settings: json.RawMessage(`{"query": "a < b && b > c"}`),
+		},
+		Expected: map[string]interface{}{
+			"settings": `{"query":"a < b && b > c"}`,
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_JSONNumbersRetainPrecision(t *testing.T) {
+	type Type struct {
+		Settings json.RawMessage `tfschema:"settings"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			// 2^53 + 1 can't be represented as a float64
+			Settings: json.RawMessage(`{"id": 9007199254740993, "ratio": 1.50, "big": 1e100}`),
+		},
+		Expected: map[string]interface{}{
+			"settings": `{"big":1e100,"id":9007199254740993,"ratio":1.50}`,
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_JSONTrailingData(t *testing.T) {
+	type Type struct {
+		Settings json.RawMessage `tfschema:"settings"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			Settings: json.RawMessage(`{"hello": "world"} {}`),
+		},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceEncode_InvalidJSON(t *testing.T) {
+	type Type struct {
+		Settings json.RawMessage `tfschema:"settings"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			Settings: json.RawMessage(`{"hello"`),
+		},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceEncode_UnsupportedType(t *testing.T) {
+	type Inner struct {
+		Channel chan string `tfschema:"channel"`
+	}
+	type Type struct {
+		Inners []Inner `tfschema:"inners"`
+	}
+
+	encodeTestData{
+		Input: &Type{
+			Inners: []Inner{
+				{},
+			},
+		},
+		ExpectError: true,
+	}.test(t)
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
-	fieldName := reflect.ValueOf(testData.Input).Elem().String()
 	debugLogger := ConsoleLogger{}
 
-	output, err := recurse(objType, objVal, fieldName, debugLogger)
+	output, err := recurse(objType, objVal, "", debugLogger)
 	if err != nil {
 		if testData.ExpectError {
 			// we're good
@@ -0,0 +1,201 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// HasChange returns whether the field within the Model has changed - where model is a pointer to the Model
+// and field is a pointer to a field within it, for example:
+//
+//	var config KeyModel
+//	if err := metadata.DecodeDiff(&config); err != nil { .. }
+//	changed, err := metadata.HasChange(&config, &config.RotationPolicy[0].ExpireAfter)
+//
+// This uses the ResourceDiff when set (e.g. within CustomizeDiff), otherwise the ResourceData
+func (rmd ResourceMetaData) HasChange(model interface{}, field interface{}) (bool, error) {
+	key, err := rmd.schemaKeyForField(model, field)
+	if err != nil {
+		return false, err
+	}
+
+	if rmd.ResourceDiff != nil {
+		return rmd.ResourceDiff.HasChange(key), nil
+	}
+	if rmd.ResourceData != nil {
+		return rmd.ResourceData.HasChange(key), nil
+	}
+
+	return false, fmt.Errorf("ResourceData and ResourceDiff were nil")
+}
+
+// SetNewComputed marks the field within the Model as Computed in the Plan, meaning that the value will be
+// known after apply - where model is a pointer to the Model and field is a pointer to a top-level field within it
+// NOTE: this can only be used within CustomizeDiff
+func (rmd ResourceMetaData) SetNewComputed(model interface{}, field interface{}) error {
+	if rmd.ResourceDiff == nil {
+		return fmt.Errorf("ResourceDiff was nil")
+	}
+
+	key, err := rmd.schemaKeyForField(model, field)
+	if err != nil {
+		return err
+	}
+
+	if err := rmd.ResourceDiff.SetNewComputed(key); err != nil {
+		return fmt.Errorf("setting %q to computed: %+v", key, err)
+	}
+	return nil
+}
+
+// ForceNew marks the Resource as requiring replacement due to a change in the field within the Model - where
+// model is a pointer to the Model and field is a pointer to a field within it
+// NOTE: this can only be used within CustomizeDiff, and only when the field has changed
+func (rmd ResourceMetaData) ForceNew(model interface{}, field interface{}) error {
+	if rmd.ResourceDiff == nil {
+		return fmt.Errorf("ResourceDiff was nil")
+	}
+
+	key, err := rmd.schemaKeyForField(model, field)
+	if err != nil {
+		return err
+	}
+
+	if err := rmd.ResourceDiff.ForceNew(key); err != nil {
+		return fmt.Errorf("forcing a new resource for %q: %+v", key, err)
+	}
+	return nil
+}
+
+// NewValueKnown returns whether the value of the field within the Model is known at plan time - where model is
+// a pointer to the Model and field is a pointer to a field within it. Values which aren't known until apply (for
+// example those referencing a Computed attribute) are decoded by DecodeDiff as their zero value, so this should
+// be checked prior to validating the value.
+//
+// This uses the ResourceDiff when set (e.g. within ValidateConfig and CustomizeDiff), otherwise all values are known
+func (rmd ResourceMetaData) NewValueKnown(model interface{}, field interface{}) (bool, error) {
+	key, err := rmd.schemaKeyForField(model, field)
+	if err != nil {
+		return false, err
+	}
+
+	if rmd.ResourceDiff != nil {
+		return rmd.ResourceDiff.NewValueKnown(key), nil
+	}
+
+	return true, nil
+}
+
+// NewAttributeErrorForField returns an AttributeError for the field within the Model - where model is a pointer
+// to the Model and field is a pointer to a field within it, for example `&config.RotationPolicy[0].ExpireAfter`
+//
+// NOTE: the Schema isn't available here, so fields within a TypeSet block (whose elements can't be addressed
+// by index) aren't rejected as they are by the ResourceMetaData functions - use NewAttributeError for these
+func NewAttributeErrorForField(model interface{}, field interface{}, summary, detail string) error {
+	key, err := schemaKeyForField(model, field, nil)
+	if err != nil {
+		return err
+	}
+
+	return NewAttributeError(key, summary, detail)
+}
+
+// schemaKeyForField returns the key in the Schema for the field within the Model, rejecting fields within
+// a TypeSet block - since the elements of a Set are addressed by their hash rather than their index
+func (rmd ResourceMetaData) schemaKeyForField(model interface{}, field interface{}) (string, error) {
+	return schemaKeyForField(model, field, rmd.isSetBacked)
+}
+
+// isSetBacked returns whether the block at the specified key is a TypeSet, using the ResourceDiff when set
+// (e.g. within CustomizeDiff), otherwise the ResourceData
+func (rmd ResourceMetaData) isSetBacked(key string) bool {
+	var value interface{}
+	if rmd.ResourceDiff != nil {
+		value = rmd.ResourceDiff.Get(key)
+	} else if rmd.ResourceData != nil {
+		value = rmd.ResourceData.Get(key)
+	}
+
+	_, ok := value.(*schema.Set)
+	return ok
+}
+
+// schemaKeyForField returns the key in the Schema (e.g. `rotation_policy.0.expire_after`) for the field
+// within the Model, using the `tfschema` struct tags - recursing into slices of nested structs (or pointers
+// to them). When isSetBacked is specified, fields within a block for which this returns true are rejected
+func schemaKeyForField(model interface{}, field interface{}, isSetBacked func(key string) bool) (string, error) {
+	modelVal := reflect.ValueOf(model)
+	if modelVal.Kind() != reflect.Ptr || modelVal.IsNil() || modelVal.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("the model must be a pointer to a struct but got %T", model)
+	}
+
+	fieldVal := reflect.ValueOf(field)
+	if fieldVal.Kind() != reflect.Ptr || fieldVal.IsNil() {
+		return "", fmt.Errorf("the field must be a pointer to a field within the model but got %T", field)
+	}
+
+	key, ok, err := findSchemaKeyForField(modelVal.Elem(), fieldVal, "", isSetBacked)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return key, nil
+	}
+
+	return "", fmt.Errorf("the field %T was not found within the model %T (or is missing a `tfschema` struct tag)", field, model)
+}
+
+func findSchemaKeyForField(structVal reflect.Value, fieldPtr reflect.Value, prefix string, isSetBacked func(key string) bool) (string, bool, error) {
+	for i := 0; i < structVal.NumField(); i++ {
+		tag, exists := structVal.Type().Field(i).Tag.Lookup("tfschema")
+		if !exists {
+			continue
+		}
+
+		key := prefix + tag
+		fieldVal := structVal.Field(i)
+		// the first field in a struct shares the address of the struct, so the types have to match too
+		if fieldVal.Addr().Pointer() == fieldPtr.Pointer() && fieldVal.Type() == fieldPtr.Type().Elem() {
+			return key, true, nil
+		}
+
+		if fieldVal.Kind() != reflect.Slice {
+			continue
+		}
+		elemType := fieldVal.Type().Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			continue
+		}
+
+		for j := 0; j < fieldVal.Len(); j++ {
+			elem := fieldVal.Index(j)
+			if elem.Kind() == reflect.Ptr {
+				if elem.IsNil() {
+					continue
+				}
+				elem = elem.Elem()
+			}
+
+			v, ok, err := findSchemaKeyForField(elem, fieldPtr, key+"."+strconv.Itoa(j)+".", isSetBacked)
+			if err != nil {
+				return "", false, err
+			}
+			if !ok {
+				continue
+			}
+			if isSetBacked != nil && isSetBacked(key) {
+				return "", false, fmt.Errorf("the field %q is within the block %q which is a TypeSet - the elements of a Set can't be addressed by index, so the block should be used instead", v, key)
+			}
+
+			return v, true, nil
+		}
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,485 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type rotatingKeyModel struct {
+	Name           string                `tfschema:"name"`
+	RotateOnChange string                `tfschema:"rotate_on_change"`
+	RotationPolicy []rotationPolicyModel `tfschema:"rotation_policy"`
+	Version        string                `tfschema:"version"`
+}
+
+type rotationPolicyModel struct {
+	ExpireAfterDays        int `tfschema:"expire_after_days"`
+	NotifyBeforeExpiryDays int `tfschema:"notify_before_expiry_days"`
+}
+
+type rotatingKeyResource struct{}
+
+var _ ResourceWithConfigValidation = rotatingKeyResource{}
+var _ ResourceWithCustomizeDiff = rotatingKeyResource{}
+
+func (r rotatingKeyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"rotate_on_change": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+		"rotation_policy": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"expire_after_days": {
+						Type:     pluginsdk.TypeInt,
+						Required: true,
+					},
+					"notify_before_expiry_days": {
+						Type:     pluginsdk.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r rotatingKeyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r rotatingKeyResource) ModelObject() interface{} {
+	return &rotatingKeyModel{}
+}
+
+func (r rotatingKeyResource) ResourceType() string {
+	return "validator_rotating_key"
+}
+
+func (r rotatingKeyResource) Create() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (r rotatingKeyResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r rotatingKeyResource) Delete() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (r rotatingKeyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return nil
+}
+
+func (r rotatingKeyResource) ValidateConfig() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var config rotatingKeyModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return err
+			}
+
+			for i := range config.RotationPolicy {
+				policy := &config.RotationPolicy[i]
+				expireAfterKnown, err := metadata.NewValueKnown(&config, &policy.ExpireAfterDays)
+				if err != nil {
+					return err
+				}
+				notifyBeforeExpiryKnown, err := metadata.NewValueKnown(&config, &policy.NotifyBeforeExpiryDays)
+				if err != nil {
+					return err
+				}
+				if !expireAfterKnown || !notifyBeforeExpiryKnown {
+					continue
+				}
+
+				if policy.NotifyBeforeExpiryDays >= policy.ExpireAfterDays {
+					return NewAttributeErrorForField(&config, &policy.NotifyBeforeExpiryDays, "must be less than `expire_after_days`", "")
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (r rotatingKeyResource) CustomizeDiff() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var config rotatingKeyModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return err
+			}
+
+			rotated, err := metadata.HasChange(&config, &config.RotateOnChange)
+			if err != nil {
+				return err
+			}
+			if rotated && metadata.ResourceDiff.Id() != "" {
+				if err := metadata.SetNewComputed(&config, &config.Version); err != nil {
+					return err
+				}
+			}
+
+			renamed, err := metadata.HasChange(&config, &config.Name)
+			if err != nil {
+				return err
+			}
+			if renamed && metadata.ResourceDiff.Id() != "" {
+				return metadata.ForceNew(&config, &config.Name)
+			}
+			return nil
+		},
+	}
+}
+
+func TestSchemaKeyForField(t *testing.T) {
+	model := rotatingKeyModel{
+		RotationPolicy: []rotationPolicyModel{{}, {}},
+	}
+	other := rotationPolicyModel{}
+
+	testData := []struct {
+		field    interface{}
+		expected string
+		error    bool
+	}{
+		{
+			field:    &model.Name,
+			expected: "name",
+		},
+		{
+			field:    &model.Version,
+			expected: "version",
+		},
+		{
+			field:    &model.RotationPolicy,
+			expected: "rotation_policy",
+		},
+		{
+			// the first field shares the address of the element, but is a different type
+			field:    &model.RotationPolicy[1].ExpireAfterDays,
+			expected: "rotation_policy.1.expire_after_days",
+		},
+		{
+			field:    &model.RotationPolicy[0].NotifyBeforeExpiryDays,
+			expected: "rotation_policy.0.notify_before_expiry_days",
+		},
+		{
+			field: &other.ExpireAfterDays,
+			error: true,
+		},
+		{
+			field: model.Name,
+			error: true,
+		},
+	}
+	for _, v := range testData {
+		actual, err := schemaKeyForField(&model, v.field, nil)
+		if v.error {
+			if err == nil {
+				t.Fatalf("expected an error for %T but got %q", v.field, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("retrieving the key for %q: %+v", v.expected, err)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+
+	if _, err := schemaKeyForField(model, &model.Name, nil); err == nil {
+		t.Fatalf("expected an error when the model isn't a pointer")
+	}
+}
+
+type nestedPointersModel struct {
+	Name     string                 `tfschema:"name"`
+	Policies []*rotationPolicyModel `tfschema:"policy"`
+	Rules    []rotationPolicyModel  `tfschema:"rule"`
+}
+
+func TestSchemaKeyForFieldWithinPointers(t *testing.T) {
+	model := nestedPointersModel{
+		Policies: []*rotationPolicyModel{{}, nil, {}},
+	}
+
+	testData := []struct {
+		field    interface{}
+		expected string
+	}{
+		{
+			field:    &model.Policies[0].ExpireAfterDays,
+			expected: "policy.0.expire_after_days",
+		},
+		{
+			// nil elements are skipped, but still count towards the index
+			field:    &model.Policies[2].NotifyBeforeExpiryDays,
+			expected: "policy.2.notify_before_expiry_days",
+		},
+	}
+	for _, v := range testData {
+		actual, err := schemaKeyForField(&model, v.field, nil)
+		if err != nil {
+			t.Fatalf("retrieving the key for %q: %+v", v.expected, err)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestSchemaKeyForFieldWithinSet(t *testing.T) {
+	nestedSchema := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"expire_after_days": {
+				Type:     pluginsdk.TypeInt,
+				Optional: true,
+			},
+			"notify_before_expiry_days": {
+				Type:     pluginsdk.TypeInt,
+				Optional: true,
+			},
+		},
+	}
+	resourceSchema := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+		"policy": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem:     nestedSchema,
+		},
+		"rule": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem:     nestedSchema,
+		},
+	}
+	metadata := ResourceMetaData{
+		ResourceData: schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+			"name": "example",
+			"policy": []interface{}{
+				map[string]interface{}{
+					"expire_after_days": 30,
+				},
+			},
+			"rule": []interface{}{
+				map[string]interface{}{
+					"expire_after_days": 30,
+				},
+			},
+		}),
+	}
+	model := nestedPointersModel{
+		Policies: []*rotationPolicyModel{{}},
+		Rules:    []rotationPolicyModel{{}},
+	}
+
+	if _, err := metadata.HasChange(&model, &model.Policies[0].ExpireAfterDays); err == nil || !strings.Contains(err.Error(), "TypeSet") {
+		t.Fatalf("expected an error for a field within a TypeSet block but got %+v", err)
+	}
+	if _, err := metadata.HasChange(&model, &model.Policies); err != nil {
+		t.Fatalf("expected no error for the TypeSet block itself but got %+v", err)
+	}
+	if _, err := metadata.HasChange(&model, &model.Rules[0].ExpireAfterDays); err != nil {
+		t.Fatalf("expected no error for a field within a TypeList block but got %+v", err)
+	}
+}
+
+func TestResourceWrapperConfigValidationAndCustomizeDiff(t *testing.T) {
+	wrapper := NewResourceWrapper(rotatingKeyResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("wrapping Resource: %+v", err)
+	}
+
+	existing := &terraform.InstanceState{
+		ID: "/some/id",
+		Attributes: map[string]string{
+			"id":                                  "/some/id",
+			"name":                                "example",
+			"rotate_on_change":                    "1",
+			"rotation_policy.#":                   "1",
+			"rotation_policy.0.expire_after_days": "30",
+			"rotation_policy.0.notify_before_expiry_days": "7",
+			"version": "abc123",
+		},
+	}
+	config := func(name, rotateOnChange string, notifyBeforeExpiryDays int) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":             name,
+			"rotate_on_change": rotateOnChange,
+			"rotation_policy": []interface{}{
+				map[string]interface{}{
+					"expire_after_days":         30,
+					"notify_before_expiry_days": notifyBeforeExpiryDays,
+				},
+			},
+		})
+	}
+
+	t.Log("validating the configuration..")
+	_, err = resource.Diff(context.TODO(), existing, config("example", "1", 30), &clients.Client{})
+	if err == nil || !strings.HasPrefix(err.Error(), "rotation_policy.0.notify_before_expiry_days: must be less than") {
+		t.Fatalf("expected an error for `rotation_policy.0.notify_before_expiry_days` but got: %+v", err)
+	}
+
+	t.Log("rotating..")
+	diff, err := resource.Diff(context.TODO(), existing, config("example", "2", 7), &clients.Client{})
+	if err != nil {
+		t.Fatalf("diffing: %+v", err)
+	}
+	if v := diff.Attributes["version"]; v == nil || !v.NewComputed {
+		t.Fatalf("expected `version` to be computed but got %+v", v)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the Resource to be updated in-place")
+	}
+
+	t.Log("renaming..")
+	diff, err = resource.Diff(context.TODO(), existing, config("renamed", "1", 7), &clients.Client{})
+	if err != nil {
+		t.Fatalf("diffing: %+v", err)
+	}
+	if v := diff.Attributes["name"]; v == nil || !v.RequiresNew {
+		t.Fatalf("expected `name` to require replacement but got %+v", v)
+	}
+}
+
+type warningRotatingKeyResource struct {
+	rotatingKeyResource
+}
+
+func (r warningRotatingKeyResource) CustomizeDiff() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			metadata.AddAttributeWarning("rotation_policy.0.expire_after_days", "short expiry", "")
+			return nil
+		},
+	}
+}
+
+func TestResourceWrapperCustomizeDiffRejectsAttributeWarnings(t *testing.T) {
+	wrapper := NewResourceWrapper(warningRotatingKeyResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("wrapping Resource: %+v", err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "example",
+		"rotation_policy": []interface{}{
+			map[string]interface{}{
+				"expire_after_days": 30,
+			},
+		},
+	})
+	_, err = resource.Diff(context.TODO(), nil, config, &clients.Client{})
+	if err == nil || !strings.Contains(err.Error(), "rotation_policy.0.expire_after_days: short expiry") {
+		t.Fatalf("expected the attribute warning to be returned as an error but got: %+v", err)
+	}
+}
+
+func TestResourceWrapperConfigValidationDiagnostics(t *testing.T) {
+	wrapper := NewResourceWrapper(rotatingKeyResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("wrapping Resource: %+v", err)
+	}
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"validator_rotating_key": resource,
+		},
+	}
+	provider.SetMeta(&clients.Client{})
+	server := schema.NewGRPCProviderServer(provider)
+
+	schemaResp, err := server.GetProviderSchema(context.TODO(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+	objectType := schemaResp.ResourceSchemas["validator_rotating_key"].ValueType().(tftypes.Object)
+	rotationPolicyType := objectType.AttributeTypes["rotation_policy"].(tftypes.List)
+
+	plan := func(expireAfterDays tftypes.Value) []*tfprotov5.Diagnostic {
+		values := make(map[string]tftypes.Value)
+		for k, v := range objectType.AttributeTypes {
+			values[k] = tftypes.NewValue(v, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "example")
+		values["rotation_policy"] = tftypes.NewValue(rotationPolicyType, []tftypes.Value{
+			tftypes.NewValue(rotationPolicyType.ElementType, map[string]tftypes.Value{
+				"expire_after_days":         expireAfterDays,
+				"notify_before_expiry_days": tftypes.NewValue(tftypes.Number, 7),
+			}),
+		})
+		config := mustDynamicValue(t, tftypes.NewValue(objectType, values))
+
+		resp, err := server.PlanResourceChange(context.TODO(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "validator_rotating_key",
+			PriorState:       mustDynamicValue(t, tftypes.NewValue(objectType, nil)),
+			ProposedNewState: config,
+			Config:           config,
+		})
+		if err != nil {
+			t.Fatalf("planning: %+v", err)
+		}
+		return resp.Diagnostics
+	}
+
+	t.Log("validating a known value..")
+	diags := plan(tftypes.NewValue(tftypes.Number, 5))
+	if len(diags) != 1 || diags[0].Severity != tfprotov5.DiagnosticSeverityError {
+		t.Fatalf("expected a single error but got %+v", diags)
+	}
+	// the Plugin SDK doesn't surface the AttributePath at plan time, so this is included in the error message
+	if diags[0].Attribute != nil {
+		t.Fatalf("expected no AttributePath but got %+v", diags[0].Attribute)
+	}
+	if !strings.HasPrefix(diags[0].Summary, "rotation_policy.0.notify_before_expiry_days: must be less than") {
+		t.Fatalf("expected the error to reference `rotation_policy.0.notify_before_expiry_days` but got %q", diags[0].Summary)
+	}
+
+	t.Log("skipping validation for an unknown value..")
+	diags = plan(tftypes.NewValue(tftypes.Number, tftypes.UnknownValue))
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics when the value is unknown but got %+v", diags)
+	}
+}
+
+func mustDynamicValue(t *testing.T, input tftypes.Value) *tfprotov5.DynamicValue {
+	v, err := tfprotov5.NewDynamicValue(input.Type(), input)
+	if err != nil {
+		t.Fatalf("encoding %+v: %+v", input, err)
+	}
+	return &v
+}
@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// LegacyResourceIDParseFunc parses a Resource ID in a legacy format (which can't be parsed using the
+// Segments of the canonical Resource ID) - returning the Resource ID in its canonical form
+type LegacyResourceIDParseFunc func(input string) (resourceids.Id, error)
+
+var _ pluginsdk.StateUpgrade = ResourceIDStateUpgrade{}
+
+// ResourceIDStateUpgrade is a State Upgrade which rewrites the `id` - and any other attributes containing
+// Resource IDs - into the canonical form of the Resource ID, for example to fix the casing of segments.
+type ResourceIDStateUpgrade struct {
+	// ResourceId is the type of Resource ID that the `id` should be (case-insensitively) parsed as
+	ResourceId resourceids.ResourceId
+
+	// LegacyParsers are used, in order, to parse the `id` when it can't be parsed as the ResourceId
+	LegacyParsers []LegacyResourceIDParseFunc
+
+	// Attributes is a map of the names of any other top-level attributes containing a Resource ID
+	// (either as a string or a list/set of strings) to the type of Resource ID they contain
+	Attributes map[string]resourceids.ResourceId
+
+	// StateSchema is a point-in-time reference to the Schema of the Resource at this version
+	StateSchema map[string]*pluginsdk.Schema
+}
+
+// ResourceIDStateMigration returns the StateUpgradeData for a Resource whose only State Migration
+// rewrites the Resource IDs into their canonical form - using the current Schema of the Resource
+// when no StateSchema is specified, since the Schema doesn't change as a part of this migration.
+//
+// Resources which already have State Upgraders should use StateUpgradeData.WithResourceIDStateUpgrade instead.
+//
+// Example Usage:
+//
+//	func (r ExampleResource) StateUpgraders() sdk.StateUpgradeData {
+//		return sdk.ResourceIDStateMigration(r, sdk.ResourceIDStateUpgrade{ResourceId: &parse.ExampleId{}})
+//	}
+func ResourceIDStateMigration(resource Resource, upgrade ResourceIDStateUpgrade) StateUpgradeData {
+	return StateUpgradeData{}.WithResourceIDStateUpgrade(resource, upgrade)
+}
+
+// WithResourceIDStateUpgrade returns a copy of the StateUpgradeData with the ResourceIDStateUpgrade appended,
+// upgrading from the current SchemaVersion to the next - so that this can be used by Resources which already
+// have State Upgraders. As with ResourceIDStateMigration, the current Schema of the Resource is used when
+// no StateSchema is specified.
+//
+// Example Usage:
+//
+//	func (r ExampleResource) StateUpgraders() sdk.StateUpgradeData {
+//		return sdk.StateUpgradeData{
+//			SchemaVersion: 1,
+//			Upgraders: map[int]pluginsdk.StateUpgrade{
+//				0: migration.ExampleV0ToV1{},
+//			},
+//		}.WithResourceIDStateUpgrade(r, sdk.ResourceIDStateUpgrade{ResourceId: &parse.ExampleId{}})
+//	}
+func (d StateUpgradeData) WithResourceIDStateUpgrade(resource Resource, upgrade ResourceIDStateUpgrade) StateUpgradeData {
+	if upgrade.StateSchema == nil {
+		upgrade.StateSchema = make(map[string]*pluginsdk.Schema)
+		for k, v := range resource.Arguments() {
+			upgrade.StateSchema[k] = v
+		}
+		for k, v := range resource.Attributes() {
+			upgrade.StateSchema[k] = v
+		}
+	}
+
+	upgraders := make(map[int]pluginsdk.StateUpgrade, len(d.Upgraders)+1)
+	for k, v := range d.Upgraders {
+		upgraders[k] = v
+	}
+	upgraders[d.SchemaVersion] = upgrade
+
+	return StateUpgradeData{
+		SchemaVersion: d.SchemaVersion + 1,
+		Upgraders:     upgraders,
+	}
+}
+
+func (u ResourceIDStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.StateSchema
+}
+
+func (u ResourceIDStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		if oldId, ok := rawState["id"].(string); ok && oldId != "" {
+			newId, err := canonicalResourceID(oldId, u.ResourceId, u.LegacyParsers)
+			if err != nil {
+				return rawState, fmt.Errorf("updating `id`: %+v", err)
+			}
+
+			log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
+			rawState["id"] = newId
+		}
+
+		for name, resourceId := range u.Attributes {
+			switch v := rawState[name].(type) {
+			case nil:
+				continue
+
+			case string:
+				if v == "" {
+					continue
+				}
+
+				newId, err := canonicalResourceID(v, resourceId, nil)
+				if err != nil {
+					return rawState, fmt.Errorf("updating %q: %+v", name, err)
+				}
+
+				log.Printf("[DEBUG] Updating %q from %q to %q", name, v, newId)
+				rawState[name] = newId
+
+			case []interface{}:
+				for i, item := range v {
+					oldId, ok := item.(string)
+					if !ok {
+						return rawState, fmt.Errorf("updating %q: expected item %d to be a string but got %T", name, i, item)
+					}
+
+					newId, err := canonicalResourceID(oldId, resourceId, nil)
+					if err != nil {
+						return rawState, fmt.Errorf("updating %q: %+v", name, err)
+					}
+
+					log.Printf("[DEBUG] Updating %q from %q to %q", fmt.Sprintf("%s.%d", name, i), oldId, newId)
+					v[i] = newId
+				}
+
+			default:
+				return rawState, fmt.Errorf("updating %q: expected a string or a list of strings but got %T", name, v)
+			}
+		}
+
+		return rawState, nil
+	}
+}
+
+// canonicalResourceID parses the input case-insensitively using the Segments of the Resource ID, falling back
+// to the legacy parsers - and returns the Resource ID with the Segments in their canonical form
+func canonicalResourceID(input string, resourceId resourceids.ResourceId, legacyParsers []LegacyResourceIDParseFunc) (string, error) {
+	parser := resourceids.NewParserFromResourceIdType(resourceId)
+	parsed, err := parser.Parse(input, true)
+	if err == nil {
+		return formatResourceIDFromSegments(resourceId.Segments(), parsed.Parsed), nil
+	}
+
+	for _, legacyParser := range legacyParsers {
+		if id, legacyErr := legacyParser(input); legacyErr == nil {
+			return id.ID(), nil
+		}
+	}
+
+	return "", fmt.Errorf("parsing %q: %+v", input, err)
+}
+
+func formatResourceIDFromSegments(segments []resourceids.Segment, parsed map[string]string) string {
+	components := make([]string, 0)
+	for _, segment := range segments {
+		// Scopes are already prefixed with a `/`
+		components = append(components, strings.TrimPrefix(parsed[segment.Name], "/"))
+	}
+
+	return fmt.Sprintf("/%s", strings.Join(components, "/"))
+}
@@ -0,0 +1,299 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestResourceIDStateUpgrade(t *testing.T) {
+	// a legacy format of `{subscriptionId}|{resourceGroupName}|{vaultName}`
+	legacyKeyVaultParser := func(input string) (resourceids.Id, error) {
+		segments := strings.Split(input, "|")
+		if len(segments) != 3 {
+			return nil, fmt.Errorf("expected 3 segments but got %d", len(segments))
+		}
+		id := commonids.NewKeyVaultID(segments[0], segments[1], segments[2])
+		return &id, nil
+	}
+
+	testData := []struct {
+		name     string
+		upgrade  ResourceIDStateUpgrade
+		input    map[string]interface{}
+		expected map[string]interface{}
+		error    bool
+	}{
+		{
+			name: "canonical id is unchanged",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+			},
+			input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"name": "vault1",
+			},
+			expected: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"name": "vault1",
+			},
+		},
+		{
+			name: "casing of the segments is fixed",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+			},
+			input: map[string]interface{}{
+				"id": "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/resourcegroups/Group1/providers/microsoft.keyvault/Vaults/Vault1",
+			},
+			expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/Group1/providers/Microsoft.KeyVault/vaults/Vault1",
+			},
+		},
+		{
+			name: "empty id is ignored",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+			},
+			input: map[string]interface{}{
+				"id": "",
+			},
+			expected: map[string]interface{}{
+				"id": "",
+			},
+		},
+		{
+			name: "invalid id without legacy parsers",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+			},
+			input: map[string]interface{}{
+				"id": "12345678-1234-9876-4563-123456789012|group1|vault1",
+			},
+			error: true,
+		},
+		{
+			name: "legacy id is parsed using the legacy parsers",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+				LegacyParsers: []LegacyResourceIDParseFunc{
+					legacyKeyVaultParser,
+				},
+			},
+			input: map[string]interface{}{
+				"id": "12345678-1234-9876-4563-123456789012|group1|vault1",
+			},
+			expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+			},
+		},
+		{
+			name: "invalid id which can't be parsed by the legacy parsers",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+				LegacyParsers: []LegacyResourceIDParseFunc{
+					legacyKeyVaultParser,
+				},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+			error: true,
+		},
+		{
+			name: "attributes are updated",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+				Attributes: map[string]resourceids.ResourceId{
+					"resource_group_id":  &commonids.ResourceGroupId{},
+					"resource_group_ids": &commonids.ResourceGroupId{},
+					"unset_id":           &commonids.ResourceGroupId{},
+				},
+			},
+			input: map[string]interface{}{
+				"id":                "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1",
+				"resource_group_ids": []interface{}{
+					"/Subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group2",
+					"/subscriptions/12345678-1234-9876-4563-123456789012/ResourceGroups/group3",
+				},
+			},
+			expected: map[string]interface{}{
+				"id":                "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+				"resource_group_ids": []interface{}{
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group2",
+					"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group3",
+				},
+			},
+		},
+		{
+			name: "invalid attribute",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+				Attributes: map[string]resourceids.ResourceId{
+					"resource_group_id": &commonids.ResourceGroupId{},
+				},
+			},
+			input: map[string]interface{}{
+				"id":                "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012",
+			},
+			error: true,
+		},
+		{
+			name: "attribute of an unsupported type",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.KeyVaultId{},
+				Attributes: map[string]resourceids.ResourceId{
+					"resource_group_id": &commonids.ResourceGroupId{},
+				},
+			},
+			input: map[string]interface{}{
+				"id":                "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+				"resource_group_id": 42,
+			},
+			error: true,
+		},
+		{
+			name: "scope",
+			upgrade: ResourceIDStateUpgrade{
+				ResourceId: &commonids.ScopeId{},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+			expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := v.upgrade.UpgradeFunc()(context.TODO(), v.input, nil)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("unexpected error for %q: %+v", v.name, err)
+		}
+		if v.error {
+			t.Fatalf("expected an error for %q but didn't get one", v.name)
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v for %q but got %+v", v.expected, v.name, actual)
+		}
+	}
+}
+
+type resourceIDStateMigrationTestResource struct{}
+
+func (resourceIDStateMigrationTestResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (resourceIDStateMigrationTestResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"vault_uri": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (resourceIDStateMigrationTestResource) ModelObject() interface{} {
+	return nil
+}
+
+func (resourceIDStateMigrationTestResource) ResourceType() string {
+	return "azurerm_example"
+}
+
+func (resourceIDStateMigrationTestResource) Create() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (resourceIDStateMigrationTestResource) Read() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (resourceIDStateMigrationTestResource) Delete() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (resourceIDStateMigrationTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return nil
+}
+
+func TestResourceIDStateMigration(t *testing.T) {
+	data := ResourceIDStateMigration(resourceIDStateMigrationTestResource{}, ResourceIDStateUpgrade{
+		ResourceId: &commonids.KeyVaultId{},
+	})
+
+	if data.SchemaVersion != 1 {
+		t.Fatalf("expected the SchemaVersion to be 1 but got %d", data.SchemaVersion)
+	}
+	upgrader, ok := data.Upgraders[0]
+	if !ok || len(data.Upgraders) != 1 {
+		t.Fatalf("expected a single State Upgrader for version 0 but got %+v", data.Upgraders)
+	}
+
+	stateSchema := upgrader.Schema()
+	for _, key := range []string{"name", "vault_uri"} {
+		if _, ok := stateSchema[key]; !ok {
+			t.Fatalf("expected %q to be present in the State Schema", key)
+		}
+	}
+
+	// the State Upgraders must be valid for the Plugin SDK
+	if upgraders := pluginsdk.StateUpgrades(data.Upgraders); len(upgraders) != 1 {
+		t.Fatalf("expected 1 Plugin SDK State Upgrader but got %d", len(upgraders))
+	}
+}
+
+func TestResourceIDStateMigrationWithExistingUpgraders(t *testing.T) {
+	existing := StateUpgradeData{
+		SchemaVersion: 2,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			0: ResourceIDStateUpgrade{},
+			1: ResourceIDStateUpgrade{},
+		},
+	}
+	data := existing.WithResourceIDStateUpgrade(resourceIDStateMigrationTestResource{}, ResourceIDStateUpgrade{
+		ResourceId: &commonids.KeyVaultId{},
+	})
+
+	if data.SchemaVersion != 3 {
+		t.Fatalf("expected the SchemaVersion to be 3 but got %d", data.SchemaVersion)
+	}
+	if len(data.Upgraders) != 3 {
+		t.Fatalf("expected 3 State Upgraders but got %d", len(data.Upgraders))
+	}
+	upgrader, ok := data.Upgraders[2].(ResourceIDStateUpgrade)
+	if !ok {
+		t.Fatalf("expected the State Upgrader for version 2 to be a ResourceIDStateUpgrade but got %T", data.Upgraders[2])
+	}
+	if _, ok := upgrader.Schema()["vault_uri"]; !ok {
+		t.Fatalf("expected %q to be present in the State Schema", "vault_uri")
+	}
+
+	// the existing StateUpgradeData shouldn't be modified
+	if existing.SchemaVersion != 2 || len(existing.Upgraders) != 2 {
+		t.Fatalf("expected the existing StateUpgradeData to be unchanged but got %+v", existing)
+	}
+}
@@ -0,0 +1,413 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TypedSchema is a Plugin-agnostic definition of the Schema for a Provider, Resource or Data Source
+//
+// This is defined in terms of the Terraform Protocol - and as such can be compiled from the Plugin SDKv2
+// Arguments and Attributes defined on a Resource/Data Source (including the implicit `id` field and `timeouts`
+// block) and then down to the Plugin Framework - allowing the Schema of a Resource to be reused when it's migrated.
+type TypedSchema struct {
+	// Version is the Schema Version used for State Migrations
+	Version int64
+
+	// Attributes is a map of the Attribute Name to the Attribute
+	Attributes map[string]TypedSchemaAttribute
+
+	// Blocks is a map of the Block Name to the Nested Block
+	Blocks map[string]TypedSchemaBlock
+}
+
+type TypedSchemaAttribute struct {
+	// Type is the Terraform Type for this Attribute, for example `tftypes.String`
+	Type tftypes.Type
+
+	Description string
+	Required    bool
+	Optional    bool
+	Computed    bool
+	Sensitive   bool
+}
+
+type TypedSchemaNestingMode string
+
+const (
+	TypedSchemaNestingModeList   TypedSchemaNestingMode = "List"
+	TypedSchemaNestingModeSet    TypedSchemaNestingMode = "Set"
+	TypedSchemaNestingModeSingle TypedSchemaNestingMode = "Single"
+)
+
+type TypedSchemaBlock struct {
+	NestingMode TypedSchemaNestingMode
+	Description string
+	MinItems    int64
+	MaxItems    int64
+
+	Attributes map[string]TypedSchemaAttribute
+	Blocks     map[string]TypedSchemaBlock
+}
+
+// TypedSchemaForResource returns the TypedSchema for the specified Resource
+func TypedSchemaForResource(resource Resource) (*TypedSchema, error) {
+	wrapper := NewResourceWrapper(resource)
+	wrapped, err := wrapper.Resource()
+	if err != nil {
+		return nil, fmt.Errorf("wrapping Resource %q: %+v", resource.ResourceType(), err)
+	}
+
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			resource.ResourceType(): wrapped,
+		},
+	}
+	resp, err := provider.GRPCProvider().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Schema for Resource %q: %+v", resource.ResourceType(), err)
+	}
+
+	return TypedSchemaFromProtocol(resp.ResourceSchemas[resource.ResourceType()])
+}
+
+// TypedSchemaForDataSource returns the TypedSchema for the specified Data Source
+func TypedSchemaForDataSource(dataSource DataSource) (*TypedSchema, error) {
+	wrapper := NewDataSourceWrapper(dataSource)
+	wrapped, err := wrapper.DataSource()
+	if err != nil {
+		return nil, fmt.Errorf("wrapping Data Source %q: %+v", dataSource.ResourceType(), err)
+	}
+
+	provider := &schema.Provider{
+		DataSourcesMap: map[string]*schema.Resource{
+			dataSource.ResourceType(): wrapped,
+		},
+	}
+	resp, err := provider.GRPCProvider().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Schema for Data Source %q: %+v", dataSource.ResourceType(), err)
+	}
+
+	return TypedSchemaFromProtocol(resp.DataSourceSchemas[dataSource.ResourceType()])
+}
+
+// TypedSchemaFromProtocol returns the TypedSchema for the specified Terraform Protocol (v5) Schema
+func TypedSchemaFromProtocol(input *tfprotov5.Schema) (*TypedSchema, error) {
+	if input == nil || input.Block == nil {
+		return nil, fmt.Errorf("the Schema was nil")
+	}
+
+	attributes, blocks := typedSchemaFromProtocolBlock(input.Block)
+	return &TypedSchema{
+		Version:    input.Version,
+		Attributes: attributes,
+		Blocks:     blocks,
+	}, nil
+}
+
+func typedSchemaFromProtocolBlock(input *tfprotov5.SchemaBlock) (map[string]TypedSchemaAttribute, map[string]TypedSchemaBlock) {
+	attributes := make(map[string]TypedSchemaAttribute)
+	for _, v := range input.Attributes {
+		attributes[v.Name] = TypedSchemaAttribute{
+			Type:        v.Type,
+			Description: v.Description,
+			Required:    v.Required,
+			Optional:    v.Optional,
+			Computed:    v.Computed,
+			Sensitive:   v.Sensitive,
+		}
+	}
+
+	blocks := make(map[string]TypedSchemaBlock)
+	for _, v := range input.BlockTypes {
+		block := TypedSchemaBlock{
+			MinItems: v.MinItems,
+			MaxItems: v.MaxItems,
+		}
+		switch v.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			block.NestingMode = TypedSchemaNestingModeSet
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			block.NestingMode = TypedSchemaNestingModeSingle
+		default:
+			block.NestingMode = TypedSchemaNestingModeList
+		}
+		if v.Block != nil {
+			block.Description = v.Block.Description
+			block.Attributes, block.Blocks = typedSchemaFromProtocolBlock(v.Block)
+		}
+		blocks[v.TypeName] = block
+	}
+
+	return attributes, blocks
+}
+
+// PluginFrameworkResourceSchema compiles this TypedSchema into a Plugin Framework Resource Schema
+//
+// NOTE: the Plugin Framework has no equivalent of MinItems/MaxItems for Blocks, as such these aren't
+// included and need to be validated separately (e.g. using a Validator on the Block).
+func (s TypedSchema) PluginFrameworkResourceSchema() (*resourceschema.Schema, error) {
+	attributes, blocks, err := s.pluginFrameworkResourceSchema(s.Attributes, s.Blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourceschema.Schema{
+		Version:    s.Version,
+		Attributes: attributes,
+		Blocks:     blocks,
+	}, nil
+}
+
+func (s TypedSchema) pluginFrameworkResourceSchema(input map[string]TypedSchemaAttribute, inputBlocks map[string]TypedSchemaBlock) (map[string]resourceschema.Attribute, map[string]resourceschema.Block, error) {
+	attributes := make(map[string]resourceschema.Attribute)
+	for k, v := range input {
+		attrType, err := pluginFrameworkAttributeType(v.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Attribute %q: %+v", k, err)
+		}
+
+		switch t := attrType.(type) {
+		case types.ListType:
+			attributes[k] = resourceschema.ListAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.SetType:
+			attributes[k] = resourceschema.SetAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.MapType:
+			attributes[k] = resourceschema.MapAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.ObjectType:
+			attributes[k] = resourceschema.ObjectAttribute{AttributeTypes: t.AttrTypes, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		default:
+			switch attrType {
+			case types.StringType:
+				attributes[k] = resourceschema.StringAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			case types.NumberType:
+				attributes[k] = resourceschema.NumberAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			case types.BoolType:
+				attributes[k] = resourceschema.BoolAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			}
+		}
+	}
+
+	blocks := make(map[string]resourceschema.Block)
+	for k, v := range inputBlocks {
+		nestedAttributes, nestedBlocks, err := s.pluginFrameworkResourceSchema(v.Attributes, v.Blocks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Block %q: %+v", k, err)
+		}
+
+		object := resourceschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		switch v.NestingMode {
+		case TypedSchemaNestingModeList:
+			blocks[k] = resourceschema.ListNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSet:
+			blocks[k] = resourceschema.SetNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSingle:
+			blocks[k] = resourceschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: v.Description}
+		default:
+			return nil, nil, fmt.Errorf("compiling Block %q: unsupported Nesting Mode %q", k, string(v.NestingMode))
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+// PluginFrameworkDataSourceSchema compiles this TypedSchema into a Plugin Framework Data Source Schema
+func (s TypedSchema) PluginFrameworkDataSourceSchema() (*datasourceschema.Schema, error) {
+	attributes, blocks, err := s.pluginFrameworkDataSourceSchema(s.Attributes, s.Blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datasourceschema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}, nil
+}
+
+func (s TypedSchema) pluginFrameworkDataSourceSchema(input map[string]TypedSchemaAttribute, inputBlocks map[string]TypedSchemaBlock) (map[string]datasourceschema.Attribute, map[string]datasourceschema.Block, error) {
+	attributes := make(map[string]datasourceschema.Attribute)
+	for k, v := range input {
+		attrType, err := pluginFrameworkAttributeType(v.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Attribute %q: %+v", k, err)
+		}
+
+		switch t := attrType.(type) {
+		case types.ListType:
+			attributes[k] = datasourceschema.ListAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.SetType:
+			attributes[k] = datasourceschema.SetAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.MapType:
+			attributes[k] = datasourceschema.MapAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		case types.ObjectType:
+			attributes[k] = datasourceschema.ObjectAttribute{AttributeTypes: t.AttrTypes, Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+		default:
+			switch attrType {
+			case types.StringType:
+				attributes[k] = datasourceschema.StringAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			case types.NumberType:
+				attributes[k] = datasourceschema.NumberAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			case types.BoolType:
+				attributes[k] = datasourceschema.BoolAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Computed: v.Computed, Sensitive: v.Sensitive}
+			}
+		}
+	}
+
+	blocks := make(map[string]datasourceschema.Block)
+	for k, v := range inputBlocks {
+		nestedAttributes, nestedBlocks, err := s.pluginFrameworkDataSourceSchema(v.Attributes, v.Blocks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Block %q: %+v", k, err)
+		}
+
+		object := datasourceschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		switch v.NestingMode {
+		case TypedSchemaNestingModeList:
+			blocks[k] = datasourceschema.ListNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSet:
+			blocks[k] = datasourceschema.SetNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSingle:
+			blocks[k] = datasourceschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: v.Description}
+		default:
+			return nil, nil, fmt.Errorf("compiling Block %q: unsupported Nesting Mode %q", k, string(v.NestingMode))
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+// PluginFrameworkProviderSchema compiles this TypedSchema into a Plugin Framework Provider Schema
+func (s TypedSchema) PluginFrameworkProviderSchema() (*providerschema.Schema, error) {
+	attributes, blocks, err := s.pluginFrameworkProviderSchema(s.Attributes, s.Blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerschema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}, nil
+}
+
+func (s TypedSchema) pluginFrameworkProviderSchema(input map[string]TypedSchemaAttribute, inputBlocks map[string]TypedSchemaBlock) (map[string]providerschema.Attribute, map[string]providerschema.Block, error) {
+	attributes := make(map[string]providerschema.Attribute)
+	for k, v := range input {
+		attrType, err := pluginFrameworkAttributeType(v.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Attribute %q: %+v", k, err)
+		}
+
+		// NOTE: Provider Attributes can't be Computed
+		switch t := attrType.(type) {
+		case types.ListType:
+			attributes[k] = providerschema.ListAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case types.SetType:
+			attributes[k] = providerschema.SetAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case types.MapType:
+			attributes[k] = providerschema.MapAttribute{ElementType: t.ElemType, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case types.ObjectType:
+			attributes[k] = providerschema.ObjectAttribute{AttributeTypes: t.AttrTypes, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		default:
+			switch attrType {
+			case types.StringType:
+				attributes[k] = providerschema.StringAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			case types.NumberType:
+				attributes[k] = providerschema.NumberAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			case types.BoolType:
+				attributes[k] = providerschema.BoolAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			}
+		}
+	}
+
+	blocks := make(map[string]providerschema.Block)
+	for k, v := range inputBlocks {
+		nestedAttributes, nestedBlocks, err := s.pluginFrameworkProviderSchema(v.Attributes, v.Blocks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compiling Block %q: %+v", k, err)
+		}
+
+		object := providerschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		switch v.NestingMode {
+		case TypedSchemaNestingModeList:
+			blocks[k] = providerschema.ListNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSet:
+			blocks[k] = providerschema.SetNestedBlock{NestedObject: object, Description: v.Description}
+		case TypedSchemaNestingModeSingle:
+			blocks[k] = providerschema.SingleNestedBlock{Attributes: nestedAttributes, Blocks: nestedBlocks, Description: v.Description}
+		default:
+			return nil, nil, fmt.Errorf("compiling Block %q: unsupported Nesting Mode %q", k, string(v.NestingMode))
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+// pluginFrameworkAttributeType returns the Plugin Framework type for the specified Terraform Type
+func pluginFrameworkAttributeType(input tftypes.Type) (attr.Type, error) {
+	switch {
+	case input == nil:
+		return nil, fmt.Errorf("the Type was nil")
+
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+
+	case input.Is(tftypes.List{}):
+		elemType, err := pluginFrameworkAttributeType(input.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elemType}, nil
+
+	case input.Is(tftypes.Set{}):
+		elemType, err := pluginFrameworkAttributeType(input.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elemType}, nil
+
+	case input.Is(tftypes.Map{}):
+		elemType, err := pluginFrameworkAttributeType(input.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elemType}, nil
+
+	case input.Is(tftypes.Object{}):
+		attrTypes := make(map[string]attr.Type)
+		for k, v := range input.(tftypes.Object).AttributeTypes {
+			attrType, err := pluginFrameworkAttributeType(v)
+			if err != nil {
+				return nil, fmt.Errorf("compiling Object Attribute %q: %+v", k, err)
+			}
+			attrTypes[k] = attrType
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported Type %s", input.String())
+}
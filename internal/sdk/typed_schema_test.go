@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"testing"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTypedSchemaForResource(t *testing.T) {
+	typedSchema, err := TypedSchemaForResource(rotatingKeyResource{})
+	if err != nil {
+		t.Fatalf("building the TypedSchema: %+v", err)
+	}
+
+	for k, expected := range map[string]TypedSchemaAttribute{
+		"id": {
+			Type:     tftypes.String,
+			Optional: true,
+			Computed: true,
+		},
+		"name": {
+			Type:     tftypes.String,
+			Required: true,
+		},
+		"rotate_on_change": {
+			Type:     tftypes.String,
+			Optional: true,
+		},
+		"version": {
+			Type:     tftypes.String,
+			Computed: true,
+		},
+	} {
+		actual, ok := typedSchema.Attributes[k]
+		if !ok {
+			t.Fatalf("expected the Attribute %q to exist but got %+v", k, typedSchema.Attributes)
+		}
+		if !actual.Type.Is(expected.Type) || actual.Required != expected.Required || actual.Optional != expected.Optional || actual.Computed != expected.Computed {
+			t.Fatalf("expected the Attribute %q to be %+v but got %+v", k, expected, actual)
+		}
+	}
+
+	rotationPolicy, ok := typedSchema.Blocks["rotation_policy"]
+	if !ok {
+		t.Fatalf("expected the Block `rotation_policy` to exist but got %+v", typedSchema.Blocks)
+	}
+	if rotationPolicy.NestingMode != TypedSchemaNestingModeList || rotationPolicy.MaxItems != 1 {
+		t.Fatalf("expected `rotation_policy` to be a List with a MaxItems of 1 but got %+v", rotationPolicy)
+	}
+	if v := rotationPolicy.Attributes["expire_after_days"]; !v.Type.Is(tftypes.Number) || !v.Required {
+		t.Fatalf("expected `rotation_policy.expire_after_days` to be a Required Number but got %+v", v)
+	}
+
+	// the `timeouts` block is added by Plugin SDKv2, based on the Timeouts defined for the Resource
+	timeouts, ok := typedSchema.Blocks["timeouts"]
+	if !ok {
+		t.Fatalf("expected the Block `timeouts` to exist but got %+v", typedSchema.Blocks)
+	}
+	for _, k := range []string{"create", "read", "delete"} {
+		if _, ok := timeouts.Attributes[k]; !ok {
+			t.Fatalf("expected `timeouts.%s` to exist but got %+v", k, timeouts.Attributes)
+		}
+	}
+
+	frameworkSchema, err := typedSchema.PluginFrameworkResourceSchema()
+	if err != nil {
+		t.Fatalf("compiling the Plugin Framework Schema: %+v", err)
+	}
+	if v, ok := frameworkSchema.Attributes["name"].(resourceschema.StringAttribute); !ok || !v.Required {
+		t.Fatalf("expected `name` to be a Required String Attribute but got %+v", frameworkSchema.Attributes["name"])
+	}
+	if v, ok := frameworkSchema.Attributes["version"].(resourceschema.StringAttribute); !ok || !v.Computed || v.Optional {
+		t.Fatalf("expected `version` to be a Computed String Attribute but got %+v", frameworkSchema.Attributes["version"])
+	}
+	block, ok := frameworkSchema.Blocks["rotation_policy"].(resourceschema.ListNestedBlock)
+	if !ok {
+		t.Fatalf("expected `rotation_policy` to be a List Nested Block but got %+v", frameworkSchema.Blocks["rotation_policy"])
+	}
+	if _, ok := block.NestedObject.Attributes["notify_before_expiry_days"].(resourceschema.NumberAttribute); !ok {
+		t.Fatalf("expected `rotation_policy.notify_before_expiry_days` to be a Number Attribute but got %+v", block.NestedObject.Attributes["notify_before_expiry_days"])
+	}
+}
+
+func TestTypedSchemaForDataSource(t *testing.T) {
+	typedSchema, err := TypedSchemaForDataSource(notFoundDataSource{})
+	if err != nil {
+		t.Fatalf("building the TypedSchema: %+v", err)
+	}
+
+	frameworkSchema, err := typedSchema.PluginFrameworkDataSourceSchema()
+	if err != nil {
+		t.Fatalf("compiling the Plugin Framework Schema: %+v", err)
+	}
+	// the fields added by the Data Source Wrapper should be included
+	if v, ok := frameworkSchema.Attributes["not_found_behaviour"].(datasourceschema.StringAttribute); !ok || !v.Optional {
+		t.Fatalf("expected `not_found_behaviour` to be an Optional String Attribute but got %+v", frameworkSchema.Attributes["not_found_behaviour"])
+	}
+	if v, ok := frameworkSchema.Attributes["exists"].(datasourceschema.BoolAttribute); !ok || !v.Computed {
+		t.Fatalf("expected `exists` to be a Computed Bool Attribute but got %+v", frameworkSchema.Attributes["exists"])
+	}
+}
+
+func TestTypedSchemaPluginFrameworkAttributeTypes(t *testing.T) {
+	typedSchema := TypedSchema{
+		Attributes: map[string]TypedSchemaAttribute{
+			"list": {
+				Type:     tftypes.List{ElementType: tftypes.String},
+				Optional: true,
+			},
+			"set": {
+				Type:     tftypes.Set{ElementType: tftypes.Number},
+				Optional: true,
+			},
+			"map": {
+				Type:      tftypes.Map{ElementType: tftypes.Bool},
+				Optional:  true,
+				Sensitive: true,
+			},
+			"object": {
+				Type: tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"nested": tftypes.List{ElementType: tftypes.String},
+					},
+				},
+				Computed: true,
+			},
+		},
+		Blocks: map[string]TypedSchemaBlock{
+			"set_block": {
+				NestingMode: TypedSchemaNestingModeSet,
+				Attributes: map[string]TypedSchemaAttribute{
+					"value": {
+						Type:     tftypes.String,
+						Required: true,
+					},
+				},
+			},
+			"single_block": {
+				NestingMode: TypedSchemaNestingModeSingle,
+			},
+		},
+	}
+
+	frameworkSchema, err := typedSchema.PluginFrameworkResourceSchema()
+	if err != nil {
+		t.Fatalf("compiling the Plugin Framework Schema: %+v", err)
+	}
+	if v, ok := frameworkSchema.Attributes["list"].(resourceschema.ListAttribute); !ok || v.ElementType != types.StringType {
+		t.Fatalf("expected `list` to be a List of Strings but got %+v", frameworkSchema.Attributes["list"])
+	}
+	if v, ok := frameworkSchema.Attributes["set"].(resourceschema.SetAttribute); !ok || v.ElementType != types.NumberType {
+		t.Fatalf("expected `set` to be a Set of Numbers but got %+v", frameworkSchema.Attributes["set"])
+	}
+	if v, ok := frameworkSchema.Attributes["map"].(resourceschema.MapAttribute); !ok || v.ElementType != types.BoolType || !v.Sensitive {
+		t.Fatalf("expected `map` to be a Sensitive Map of Bools but got %+v", frameworkSchema.Attributes["map"])
+	}
+	object, ok := frameworkSchema.Attributes["object"].(resourceschema.ObjectAttribute)
+	if !ok || !object.AttributeTypes["nested"].Equal(types.ListType{ElemType: types.StringType}) {
+		t.Fatalf("expected `object` to be an Object containing a List of Strings but got %+v", frameworkSchema.Attributes["object"])
+	}
+	if _, ok := frameworkSchema.Blocks["set_block"].(resourceschema.SetNestedBlock); !ok {
+		t.Fatalf("expected `set_block` to be a Set Nested Block but got %+v", frameworkSchema.Blocks["set_block"])
+	}
+	if _, ok := frameworkSchema.Blocks["single_block"].(resourceschema.SingleNestedBlock); !ok {
+		t.Fatalf("expected `single_block` to be a Single Nested Block but got %+v", frameworkSchema.Blocks["single_block"])
+	}
+
+	unsupported := TypedSchema{
+		Attributes: map[string]TypedSchemaAttribute{
+			"dynamic": {
+				Type:     tftypes.DynamicPseudoType,
+				Optional: true,
+			},
+		},
+	}
+	if _, err := unsupported.PluginFrameworkResourceSchema(); err == nil {
+		t.Fatalf("expected an error for an unsupported Type")
+	}
+}
@@ -32,20 +32,32 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 
 	modelObj := dw.dataSource.ModelObject()
 	if modelObj != nil {
-		if err := ValidateModelObject(modelObj); err != nil {
+		if err := ValidateModelObjectAgainstSchema(modelObj, *resourceSchema); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", dw.dataSource.ResourceType(), err)
 		}
 	}
 
+	// the `not_found_behaviour` and `exists` fields are managed by the wrapper, so aren't part of the model
+	notFoundBehaviour, hasNotFoundBehaviour := dw.dataSource.(DataSourceWithNotFoundBehaviour)
+	if hasNotFoundBehaviour {
+		if err := notFoundBehaviourSchema(*resourceSchema, notFoundBehaviour.DefaultNotFoundBehaviour()); err != nil {
+			return nil, fmt.Errorf("building Schema: %+v", err)
+		}
+	}
+
 	d := func(duration time.Duration) *time.Duration {
 		return &duration
 	}
 
 	resource := schema.Resource{
 		Schema: *resourceSchema,
-		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, dw.logger)
-			return dw.dataSource.Read().Func(ctx, metaData)
+		ReadContext: dw.diagnosticsWrapper("read", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+			metaData := runArgs(d, meta, logger)
+			err := dw.dataSource.Read().Func(ctx, metaData)
+			if hasNotFoundBehaviour {
+				return handleNotFoundBehaviour(metaData, err)
+			}
+			return err
 		}),
 		Timeouts: &schema.ResourceTimeout{
 			Read: d(dw.dataSource.Read().Timeout),
@@ -55,6 +67,6 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 	return &resource, nil
 }
 
-func (dw *DataSourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) schema.ReadContextFunc {
-	return diagnosticsWrapper(in, dw.logger)
+func (dw *DataSourceWrapper) diagnosticsWrapper(operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error) schema.ReadContextFunc {
+	return diagnosticsWrapper(operation, in, dw.logger)
 }
@@ -47,10 +47,11 @@ func combineSchema(arguments map[string]*schema.Schema, attributes map[string]*s
 func runArgs(d *schema.ResourceData, meta interface{}, logger Logger) ResourceMetaData {
 	client := meta.(*clients.Client)
 	metaData := ResourceMetaData{
-		Client:                   client,
-		Logger:                   logger,
-		ResourceData:             d,
-		serializationDebugLogger: NullLogger{},
+		Client:       client,
+		Logger:       logger,
+		ResourceData: d,
+		// the output from serializing and deserializing the Model is verbose, so is only written at the Trace level
+		serializationDebugLogger: traceLogger{logger: logger},
 	}
 
 	return metaData
@@ -35,7 +35,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 	modelObj := rw.resource.ModelObject()
 	if modelObj != nil {
-		if err := ValidateModelObject(modelObj); err != nil {
+		if err := ValidateModelObjectAgainstSchema(modelObj, *resourceSchema); err != nil {
 			return nil, fmt.Errorf("validating model for %q: %+v", rw.resource.ResourceType(), err)
 		}
 	}
@@ -47,8 +47,8 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 
-		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+		CreateContext: rw.diagnosticsWrapper("create", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+			metaData := runArgs(d, meta, logger)
 			err := rw.resource.Create().Func(ctx, metaData)
 			if err != nil {
 				return err
@@ -56,16 +56,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.readAfterWrite(ctx, metaData)
 		}),
 
 		// looks like these could be reused, easiest if they're not
-		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+		ReadContext: rw.diagnosticsWrapper("read", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+			metaData := runArgs(d, meta, logger)
 			return rw.resource.Read().Func(ctx, metaData)
 		}),
-		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+		DeleteContext: rw.diagnosticsWrapper("delete", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+			metaData := runArgs(d, meta, logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
 
@@ -78,8 +78,9 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			fn := rw.resource.IDValidationFunc()
 			warnings, errors := fn(id, "id")
 			if len(warnings) > 0 {
+				logger := rw.logger.WithFields(operationLogFields("import", id))
 				for _, warning := range warnings {
-					rw.logger.Warn(warning)
+					logger.Warn(warning)
 				}
 			}
 			if len(errors) > 0 {
@@ -93,12 +94,16 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			return nil
 		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 			if v, ok := rw.resource.(ResourceWithCustomImporter); ok {
-				metaData := runArgs(d, meta, rw.logger)
+				logger := newOperationLoggerWithoutDiagnostics(ctx, rw.logger, operationLogFields("import", d.Id()))
+				metaData := runArgs(d, meta, logger)
 
 				err := v.CustomImporter()(ctx, metaData)
 				if err != nil {
 					return nil, err
 				}
+				if err := rejectedWarningsError("import", logger); err != nil {
+					return nil, err
+				}
 
 				return []*pluginsdk.ResourceData{metaData.ResourceData}, nil
 			}
@@ -110,8 +115,8 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 	// Not all resources support update - so this is an separate interface
 	// implementations can opt to interface
 	if v, ok := rw.resource.(ResourceWithUpdate); ok {
-		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-			metaData := runArgs(d, meta, rw.logger)
+		resource.UpdateContext = rw.diagnosticsWrapper("update", func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error {
+			metaData := runArgs(d, meta, logger)
 
 			err := v.Update().Func(ctx, metaData)
 			if err != nil {
@@ -120,22 +125,38 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.readAfterWrite(ctx, metaData)
 		})
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
 
-	if v, ok := rw.resource.(ResourceWithCustomizeDiff); ok {
+	validateConfig, hasConfigValidation := rw.resource.(ResourceWithConfigValidation)
+	customizeDiff, hasCustomizeDiff := rw.resource.(ResourceWithCustomizeDiff)
+	if hasConfigValidation || hasCustomizeDiff {
 		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 			client := meta.(*clients.Client)
+			logger := newOperationLoggerWithoutDiagnostics(ctx, rw.logger, operationLogFields("customize_diff", d.Id()))
 			metaData := ResourceMetaData{
 				Client:                   client,
-				Logger:                   rw.logger,
+				Logger:                   logger,
 				ResourceDiff:             d,
-				serializationDebugLogger: NullLogger{},
+				serializationDebugLogger: traceLogger{logger: logger},
+			}
+
+			// the Configuration is validated first, so that CustomizeDiff can rely on it being valid
+			if hasConfigValidation {
+				if err := validateConfig.ValidateConfig().Func(ctx, metaData); err != nil {
+					return err
+				}
+			}
+
+			if hasCustomizeDiff {
+				if err := customizeDiff.CustomizeDiff().Func(ctx, metaData); err != nil {
+					return err
+				}
 			}
 
-			return v.CustomizeDiff().Func(ctx, metaData)
+			return rejectedWarningsError("customize_diff", logger)
 		}
 	}
 
@@ -175,26 +196,124 @@ and we recommend using the %[2]q resource instead.
 	return &resource, nil
 }
 
-func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diagnosticsWrapper(in, rw.logger)
+// readAfterWriteMinTimeout is the minimum duration between the Reads performed whilst waiting for an eventually
+// consistent Resource to be returned, which is increased exponentially by the Plugin SDK
+var readAfterWriteMinTimeout = 5 * time.Second
+
+// readAfterWrite calls the Read function following a Create or Update - retrying whilst the Resource is marked
+// as gone if it implements ResourceWithEventualConsistency, rather than removing the newly written Resource from state
+func (rw *ResourceWrapper) readAfterWrite(ctx context.Context, metaData ResourceMetaData) error {
+	v, ok := rw.resource.(ResourceWithEventualConsistency)
+	if !ok {
+		return rw.resource.Read().Func(ctx, metaData)
+	}
+
+	id := metaData.ResourceData.Id()
+	if id == "" {
+		return rw.resource.Read().Func(ctx, metaData)
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"NotFound"},
+		Target:  []string{"Found"},
+		Refresh: func() (interface{}, string, error) {
+			if err := rw.resource.Read().Func(ctx, metaData); err != nil {
+				return nil, "", err
+			}
+
+			if metaData.ResourceData.Id() == "" {
+				metaData.Logger.Debugf("%q was not found after being written - retrying..", id)
+				metaData.ResourceData.SetId(id)
+				return id, "NotFound", nil
+			}
+
+			return id, "Found", nil
+		},
+		MinTimeout: readAfterWriteMinTimeout,
+		Timeout:    v.ConsistencyWindow(),
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < stateConf.Timeout {
+		stateConf.Timeout = time.Until(deadline)
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %q to be returned by the API after being written: %+v", id, err)
+	}
+
+	return nil
+}
+
+func (rw *ResourceWrapper) diagnosticsWrapper(operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diagnosticsWrapper(operation, in, rw.logger)
 }
 
-func diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error, logger Logger) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func diagnosticsWrapper(operation string, in func(ctx context.Context, d *schema.ResourceData, meta interface{}, logger Logger) error, logger Logger) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		id := ""
+		if d != nil {
+			id = d.Id()
+		}
+		operationLogger := newOperationLogger(ctx, logger, operationLogFields(operation, id))
+
 		out := make([]diag.Diagnostic, 0)
-		if err := in(ctx, d, meta); err != nil {
-			out = append(out, diag.Diagnostic{
-				Severity:      diag.Error,
-				Summary:       err.Error(),
-				Detail:        err.Error(),
-				AttributePath: nil,
-			})
+		if err := in(ctx, d, meta, operationLogger); err != nil {
+			out = append(out, diagnosticForError(err))
 		}
 
-		if diagsLogger, ok := logger.(*DiagnosticsLogger); ok {
+		if diagsLogger, ok := operationLogger.(*DiagnosticsLogger); ok {
 			out = append(out, diagsLogger.diagnostics...)
 		}
 
 		return out
 	}
 }
+
+// newOperationLogger returns the Logger to use for a single operation, since the warnings collected by a
+// DiagnosticsLogger are specific to the instance of the resource being operated on
+func newOperationLogger(ctx context.Context, logger Logger, fields map[string]interface{}) Logger {
+	if _, ok := logger.(*DiagnosticsLogger); ok {
+		return &DiagnosticsLogger{
+			ctx:    ctx,
+			fields: fields,
+		}
+	}
+
+	return logger.WithFields(fields)
+}
+
+// newOperationLoggerWithoutDiagnostics returns the Logger to use for a single operation where the Plugin SDK
+// can't surface Diagnostics (such as CustomizeDiff and Import) - any warnings are written to the log, with
+// attribute warnings being returned from rejectedWarningsError rather than being silently dropped
+func newOperationLoggerWithoutDiagnostics(ctx context.Context, logger Logger, fields map[string]interface{}) Logger {
+	if _, ok := logger.(*DiagnosticsLogger); ok {
+		return &DiagnosticsLogger{
+			ctx:                ctx,
+			fields:             fields,
+			withoutDiagnostics: true,
+		}
+	}
+
+	return logger.WithFields(fields)
+}
+
+// rejectedWarningsError returns an error for any attribute warnings raised during an operation
+// where the Plugin SDK can't surface Diagnostics
+func rejectedWarningsError(operation string, logger Logger) error {
+	if v, ok := logger.(*DiagnosticsLogger); ok {
+		return v.rejectedWarningsError(operation)
+	}
+
+	return nil
+}
+
+// operationLogFields returns the fields included in each log message for an operation, which allows
+// the log output for a single instance of a resource to be filtered
+func operationLogFields(operation, id string) map[string]interface{} {
+	fields := map[string]interface{}{
+		"operation": operation,
+	}
+	if id != "" {
+		fields["resource_id"] = id
+	}
+	return fields
+}
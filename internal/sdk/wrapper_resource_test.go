@@ -0,0 +1,152 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type eventuallyConsistentResource struct {
+	// notFoundReads is the number of Reads which mark the Resource as gone before it's returned
+	notFoundReads int
+	reads         int
+	window        time.Duration
+}
+
+var _ ResourceWithEventualConsistency = &eventuallyConsistentResource{}
+
+func (r *eventuallyConsistentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func (r *eventuallyConsistentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r *eventuallyConsistentResource) ModelObject() interface{} {
+	return nil
+}
+
+func (r *eventuallyConsistentResource) ResourceType() string {
+	return "validator_eventually_consistent"
+}
+
+func (r *eventuallyConsistentResource) Create() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (r *eventuallyConsistentResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			r.reads++
+			if r.reads <= r.notFoundReads {
+				return metadata.MarkAsGone(fakeResourceId{})
+			}
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+func (r *eventuallyConsistentResource) Delete() ResourceFunc {
+	return ResourceFunc{}
+}
+
+func (r *eventuallyConsistentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return nil
+}
+
+func (r *eventuallyConsistentResource) ConsistencyWindow() time.Duration {
+	return r.window
+}
+
+type fakeResourceId struct{}
+
+func (fakeResourceId) ID() string {
+	return "/some/id"
+}
+
+func (fakeResourceId) String() string {
+	return "Fake Resource"
+}
+
+func TestResourceWrapperReadAfterWrite(t *testing.T) {
+	defaultMinTimeout := readAfterWriteMinTimeout
+	readAfterWriteMinTimeout = 10 * time.Millisecond
+	defer func() {
+		readAfterWriteMinTimeout = defaultMinTimeout
+	}()
+
+	testData := []struct {
+		name          string
+		notFoundReads int
+		window        time.Duration
+		expectedReads int
+		expectError   bool
+	}{
+		{
+			name:          "returned immediately",
+			notFoundReads: 0,
+			window:        time.Minute,
+			expectedReads: 1,
+		},
+		{
+			name:          "returned within the consistency window",
+			notFoundReads: 2,
+			window:        time.Minute,
+			expectedReads: 3,
+		},
+		{
+			name:          "not returned within the consistency window",
+			notFoundReads: 1000,
+			window:        100 * time.Millisecond,
+			expectError:   true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		resource := &eventuallyConsistentResource{
+			notFoundReads: v.notFoundReads,
+			window:        v.window,
+		}
+		wrapper := NewResourceWrapper(resource)
+		d := (&schema.Resource{Schema: resource.Arguments()}).TestResourceData()
+		d.SetId("/some/id")
+		metadata := ResourceMetaData{
+			Logger:       NullLogger{},
+			ResourceData: d,
+		}
+
+		err := wrapper.readAfterWrite(context.TODO(), metadata)
+		if v.expectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			if !strings.Contains(err.Error(), "/some/id") {
+				t.Fatalf("expected the error to contain the ID but got %+v", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if resource.reads != v.expectedReads {
+			t.Fatalf("expected %d reads but got %d", v.expectedReads, resource.reads)
+		}
+		if d.Id() != "/some/id" {
+			t.Fatalf("expected the ID to be retained but got %q", d.Id())
+		}
+	}
+}
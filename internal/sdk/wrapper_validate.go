@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ValidateModelObject validates that the object contains the specified `tfschema` tags
@@ -18,8 +20,6 @@ func ValidateModelObject(input interface{}) error {
 		return fmt.Errorf("need a pointer to the model object")
 	}
 
-	// TODO: could we also validate that each `tfschema` tag exists in the schema?
-
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
 
@@ -46,9 +46,12 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
 
-		if field.Type.Kind() == reflect.Slice {
+		if field.Type.Kind() == reflect.Slice && isNestedModelObject(field.Type.Elem()) {
 			sv := fieldVal.Slice(0, fieldVal.Len())
 			innerType := sv.Type().Elem()
+			if innerType.Kind() == reflect.Ptr {
+				innerType = innerType.Elem()
+			}
 			innerVal := reflect.Indirect(reflect.New(innerType))
 			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
@@ -64,3 +67,166 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 
 	return nil
 }
+
+// ValidateModelObjectAgainstSchema validates that each `tfschema` tag within the model object exists within
+// the specified Schema, and that the type of each field is compatible with the type of the Schema field
+func ValidateModelObjectAgainstSchema(input interface{}, resourceSchema map[string]*schema.Schema) error {
+	if err := ValidateModelObject(input); err != nil {
+		return err
+	}
+	if input == nil {
+		return nil
+	}
+
+	return validateModelObjectAgainstSchemaRecursively("", reflect.TypeOf(input).Elem(), resourceSchema)
+}
+
+func validateModelObjectAgainstSchemaRecursively(prefix string, objType reflect.Type, resourceSchema map[string]*schema.Schema) error {
+	if objType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected %q to be a struct but got %s", strings.TrimPrefix(prefix, "."), objType.Kind())
+	}
+
+	fieldsInModel := make(map[string]struct{})
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		tag := field.Tag.Get("tfschema")
+		key := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, tag), ".")
+
+		if _, exists := fieldsInModel[tag]; exists {
+			return fmt.Errorf("the `tfschema` tag %q is used by more than one field", key)
+		}
+		fieldsInModel[tag] = struct{}{}
+
+		fieldSchema, exists := resourceSchema[tag]
+		if !exists {
+			return fmt.Errorf("field %q has the `tfschema` tag %q which doesn't exist in the schema", field.Name, key)
+		}
+
+		if err := validateModelFieldAgainstSchema(key, field.Type, fieldSchema); err != nil {
+			return err
+		}
+	}
+
+	// since Computed-only fields can't be configured, Encode is the only way these will be populated
+	for key, fieldSchema := range resourceSchema {
+		if prefix == "" && key == "id" {
+			continue
+		}
+		if !fieldSchema.Computed || fieldSchema.Optional || fieldSchema.Required {
+			continue
+		}
+		if _, exists := fieldsInModel[key]; !exists {
+			return fmt.Errorf("the Computed field %q has no corresponding field in the model", strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, key), "."))
+		}
+	}
+
+	return nil
+}
+
+func validateModelFieldAgainstSchema(key string, fieldType reflect.Type, fieldSchema *schema.Schema) error {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+		if fieldType.Kind() == reflect.Ptr {
+			return fmt.Errorf("field %q is a pointer to a pointer which isn't supported by Encode/Decode", key)
+		}
+	}
+
+	// these are stored as strings within the Schema, rather than by their kind
+	if fieldType == timeType || fieldType == rawMessageType {
+		if fieldSchema.Type != schema.TypeString {
+			return fmt.Errorf("field %q is a %s in the schema but is a %s in the model which must be a TypeString", key, fieldSchema.Type, fieldType)
+		}
+		return nil
+	}
+
+	switch fieldSchema.Type {
+	case schema.TypeList, schema.TypeSet:
+		if fieldType.Kind() != reflect.Slice {
+			return fmt.Errorf("field %q is a %s in the schema so must be a slice in the model but got %s", key, fieldSchema.Type, fieldType.Kind())
+		}
+
+		switch elem := fieldSchema.Elem.(type) {
+		case *schema.Resource:
+			if !isNestedModelObject(fieldType.Elem()) {
+				return fmt.Errorf("field %q is a nested block in the schema so must be a slice of structs in the model but got %s", key, fieldType)
+			}
+			nestedType := fieldType.Elem()
+			if nestedType.Kind() == reflect.Ptr {
+				nestedType = nestedType.Elem()
+			}
+			return validateModelObjectAgainstSchemaRecursively(key, nestedType, elem.Schema)
+
+		case *schema.Schema:
+			return validateModelFieldTypeAgainstSchemaType(key, fieldType.Elem(), elem.Type)
+
+		default:
+			return fmt.Errorf("field %q has an unsupported Elem type %T in the schema", key, fieldSchema.Elem)
+		}
+
+	case schema.TypeMap:
+		if fieldType.Kind() != reflect.Map {
+			return fmt.Errorf("field %q is a %s in the schema so must be a map in the model but got %s", key, fieldSchema.Type, fieldType.Kind())
+		}
+		if fieldType.Key().Kind() != reflect.String {
+			return fmt.Errorf("field %q must be a map with string keys but got %s keys", key, fieldType.Key().Kind())
+		}
+
+		if fieldType.Elem().Kind() == reflect.Interface {
+			return nil
+		}
+
+		// the Plugin SDK treats a Map without an Elem as a map of strings
+		if elem, ok := fieldSchema.Elem.(*schema.Schema); ok && elem != nil {
+			return validateModelFieldTypeAgainstSchemaType(key, fieldType.Elem(), elem.Type)
+		}
+		return validateModelFieldTypeAgainstSchemaType(key, fieldType.Elem(), schema.TypeString)
+
+	default:
+		return validateModelFieldTypeAgainstSchemaType(key, fieldType, fieldSchema.Type)
+	}
+}
+
+func validateModelFieldTypeAgainstSchemaType(key string, fieldType reflect.Type, schemaType schema.ValueType) error {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	var compatible bool
+	switch schemaType {
+	case schema.TypeBool:
+		compatible = fieldType.Kind() == reflect.Bool
+
+	case schema.TypeInt:
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			compatible = true
+		}
+
+	case schema.TypeFloat:
+		switch fieldType.Kind() {
+		case reflect.Float32, reflect.Float64:
+			compatible = true
+		}
+
+	case schema.TypeString:
+		compatible = fieldType.Kind() == reflect.String || fieldType == timeType || fieldType == rawMessageType
+
+	default:
+		return fmt.Errorf("field %q is a %s in the schema which isn't supported as an element", key, schemaType)
+	}
+
+	if !compatible {
+		return fmt.Errorf("field %q is a %s in the schema but is a %s in the model", key, schemaType, fieldType.Kind())
+	}
+
+	return nil
+}
+
+// isNestedModelObject returns whether the specified type is a struct (or a pointer to one) representing a nested block
+func isNestedModelObject(input reflect.Type) bool {
+	if input.Kind() == reflect.Ptr {
+		input = input.Elem()
+	}
+
+	return input.Kind() == reflect.Struct && input != timeType
+}
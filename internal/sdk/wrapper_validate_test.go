@@ -1,6 +1,12 @@
 package sdk
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestValidateTopLevelObjectValid(t *testing.T) {
 	type Person struct {
@@ -66,3 +72,236 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateModelObjectAgainstSchemaValid(t *testing.T) {
+	type Pet struct {
+		Name string `tfschema:"name"`
+		Age  int    `tfschema:"age"`
+	}
+	type Person struct {
+		Name     string            `tfschema:"name"`
+		Enabled  bool              `tfschema:"enabled"`
+		Weight   float64           `tfschema:"weight"`
+		Aliases  []string          `tfschema:"aliases"`
+		Numbers  []int             `tfschema:"numbers"`
+		Tags     map[string]string `tfschema:"tags"`
+		Pets     []Pet             `tfschema:"pets"`
+		Location string            `tfschema:"location"`
+		Nickname *string           `tfschema:"nickname"`
+		Birthday time.Time         `tfschema:"birthday"`
+		Profile  json.RawMessage   `tfschema:"profile"`
+	}
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"weight": {
+			Type:     schema.TypeFloat,
+			Optional: true,
+		},
+		"aliases": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"numbers": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+		"pets": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"age": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"location": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"nickname": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"birthday": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"profile": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"not_in_model": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+	if err := ValidateModelObjectAgainstSchema(&Person{}, resourceSchema); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+}
+
+func TestValidateModelObjectAgainstSchemaInvalid(t *testing.T) {
+	petSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	testData := []struct {
+		name   string
+		model  interface{}
+		schema map[string]*schema.Schema
+	}{
+		{
+			name: "tag missing from the schema",
+			model: &struct {
+				Name string `tfschema:"nmae"`
+			}{},
+			schema: petSchema,
+		},
+		{
+			name: "tag missing from a nested schema",
+			model: &struct {
+				Pets []struct {
+					Name string `tfschema:"nmae"`
+				} `tfschema:"pets"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"pets": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: petSchema,
+					},
+				},
+			},
+		},
+		{
+			name: "primitive type mismatch",
+			model: &struct {
+				Name int `tfschema:"name"`
+			}{},
+			schema: petSchema,
+		},
+		{
+			name: "list in the schema but map in the model",
+			model: &struct {
+				Pets map[string]string `tfschema:"pets"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"pets": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+		{
+			name: "set element type mismatch",
+			model: &struct {
+				Numbers []string `tfschema:"numbers"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"numbers": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
+			},
+		},
+		{
+			name: "pointer to a pointer field",
+			model: &struct {
+				Name **string `tfschema:"name"`
+			}{},
+			schema: petSchema,
+		},
+		{
+			name: "computed field missing from the model",
+			model: &struct {
+				Name string `tfschema:"name"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"location": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+		{
+			name: "time which isn't a string in the schema",
+			model: &struct {
+				Name time.Time `tfschema:"name"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+			},
+		},
+		{
+			name: "tag used more than once",
+			model: &struct {
+				Name  string `tfschema:"name"`
+				Name2 string `tfschema:"name"`
+			}{},
+			schema: petSchema,
+		},
+		{
+			name: "nested block in the schema but primitive slice in the model",
+			model: &struct {
+				Pets []string `tfschema:"pets"`
+			}{},
+			schema: map[string]*schema.Schema{
+				"pets": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: petSchema,
+					},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+		if err := ValidateModelObjectAgainstSchema(v.model, v.schema); err == nil {
+			t.Fatalf("expected an error for %q but didn't get one", v.name)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// accessPolicyPrincipalFields maps the fields which can be used in place of an `object_id` to the type of
+// principal they identify - these are resolved to an Object ID using Microsoft Graph
+var accessPolicyPrincipalFields = map[string]client.PrincipalType{
+	"group_display_name":          client.PrincipalTypeGroup,
+	"service_principal_client_id": client.PrincipalTypeServicePrincipal,
+	"user_principal_name":         client.PrincipalTypeUser,
+}
+
+// resolveAccessPolicyObjectId returns the Object ID for the principal referenced in the specified Access Policy,
+// returning nil when none of the principal fields are set
+func resolveAccessPolicyObjectId(ctx context.Context, client *client.Client, input map[string]interface{}) (*string, error) {
+	for field, principalType := range accessPolicyPrincipalFields {
+		v, ok := input[field].(string)
+		if !ok || v == "" {
+			continue
+		}
+
+		objectId, err := client.ObjectIdForPrincipal(ctx, principalType, v)
+		if err != nil {
+			return nil, fmt.Errorf("resolving the Object ID for `%s` %q: %+v", field, v, err)
+		}
+		return objectId, nil
+	}
+
+	return nil, nil
+}
+
+// resolveAccessPolicyObjectIds populates the `object_id` for each Access Policy where a principal field is set
+func resolveAccessPolicyObjectIds(ctx context.Context, client *client.Client, policies []KeyVaultAccessPolicyModel) error {
+	for i, policy := range policies {
+		objectId, err := resolveAccessPolicyObjectId(ctx, client, map[string]interface{}{
+			"group_display_name":          policy.GroupDisplayName,
+			"service_principal_client_id": policy.ServicePrincipalClientId,
+			"user_principal_name":         policy.UserPrincipalName,
+		})
+		if err != nil {
+			return err
+		}
+		if objectId != nil {
+			policies[i].ObjectId = *objectId
+		}
+
+		if policies[i].ObjectId == "" {
+			return fmt.Errorf("one of `object_id`, `user_principal_name`, `group_display_name` or `service_principal_client_id` must be specified within each `access_policy` block")
+		}
+	}
+
+	return nil
+}
+
+// keyVaultAccessPolicyPrincipalCustomizeDiff resolves the `object_id` of the `azurerm_key_vault_access_policy` resource
+// at plan time when the principal is referenced by name
+func keyVaultAccessPolicyPrincipalCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	for field := range accessPolicyPrincipalFields {
+		if !d.NewValueKnown(field) {
+			return d.SetNewComputed("object_id")
+		}
+	}
+
+	objectId, err := resolveAccessPolicyObjectId(ctx, meta.(*clients.Client).KeyVault, map[string]interface{}{
+		"group_display_name":          d.Get("group_display_name"),
+		"service_principal_client_id": d.Get("service_principal_client_id"),
+		"user_principal_name":         d.Get("user_principal_name"),
+	})
+	if err != nil {
+		return err
+	}
+	if objectId != nil && !strings.EqualFold(d.Get("object_id").(string), *objectId) {
+		return d.SetNew("object_id", *objectId)
+	}
+
+	return nil
+}
+
+// keyVaultAccessPoliciesPrincipalCustomizeDiff resolves the `object_id` within each `access_policy` block of the
+// Key Vault at plan time when the principal is referenced by name
+func keyVaultAccessPoliciesPrincipalCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("access_policy") {
+		return nil
+	}
+
+	policies := d.Get("access_policy").([]interface{})
+	changed := false
+	for i, v := range policies {
+		policy, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		known := true
+		for field := range accessPolicyPrincipalFields {
+			known = known && d.NewValueKnown(fmt.Sprintf("access_policy.%d.%s", i, field))
+		}
+		if !known {
+			// this is resolved during the apply once the value is known
+			continue
+		}
+
+		specified := make([]string, 0)
+		for field := range accessPolicyPrincipalFields {
+			if v, ok := policy[field].(string); ok && v != "" {
+				specified = append(specified, fmt.Sprintf("`%s`", field))
+			}
+		}
+		if len(specified) > 1 {
+			sort.Strings(specified)
+			return fmt.Errorf("`access_policy.%d`: only one of %s can be specified", i, strings.Join(specified, ", "))
+		}
+		// the `object_id` is Computed when a principal field is used, so this is only a conflict when it's in the config
+		if len(specified) == 1 && accessPolicyObjectIdIsConfigured(d, i) {
+			return fmt.Errorf("`access_policy.%d`: only one of `object_id` or %s can be specified", i, specified[0])
+		}
+
+		objectId, err := resolveAccessPolicyObjectId(ctx, meta.(*clients.Client).KeyVault, policy)
+		if err != nil {
+			return fmt.Errorf("`access_policy.%d`: %+v", i, err)
+		}
+		if objectId != nil && !strings.EqualFold(policy["object_id"].(string), *objectId) {
+			policy["object_id"] = *objectId
+			changed = true
+		}
+	}
+
+	if changed {
+		return d.SetNew("access_policy", policies)
+	}
+
+	return nil
+}
+
+// accessPolicyObjectIdIsConfigured returns whether the `object_id` is specified in the configuration for the
+// Access Policy at the specified index, rather than being a Computed value from the prior state
+func accessPolicyObjectIdIsConfigured(d *pluginsdk.ResourceDiff, index int) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("access_policy") {
+		return false
+	}
+
+	policies := config.GetAttr("access_policy")
+	if policies.IsNull() || !policies.IsKnown() || !policies.CanIterateElements() || policies.LengthInt() <= index {
+		return false
+	}
+
+	policy := policies.Index(cty.NumberIntVal(int64(index)))
+	if policy.IsNull() || !policy.Type().IsObjectType() || !policy.Type().HasAttribute("object_id") {
+		return false
+	}
+
+	return !policy.GetAttr("object_id").IsNull()
+}
+
+// setAccessPolicyPrincipalFields copies the principal fields from the existing Access Policies into the flattened
+// Access Policies, since the API only returns the `object_id`
+func setAccessPolicyPrincipalFields(flattened []KeyVaultAccessPolicyModel, existing []KeyVaultAccessPolicyModel) {
+	for _, policy := range existing {
+		for i, item := range flattened {
+			matches := strings.EqualFold(policy.TenantId, item.TenantId) &&
+				strings.EqualFold(policy.ObjectId, item.ObjectId) &&
+				strings.EqualFold(policy.ApplicationId, item.ApplicationId)
+			if !matches {
+				continue
+			}
+
+			flattened[i].GroupDisplayName = policy.GroupDisplayName
+			flattened[i].ServicePrincipalClientId = policy.ServicePrincipalClientId
+			flattened[i].UserPrincipalName = policy.UserPrincipalName
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package keyvault
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	keyVaultClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestKeyVaultAccessPoliciesPrincipalCustomizeDiffObjectIdConflict(t *testing.T) {
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"validator_key_vault": {
+				Schema: map[string]*pluginsdk.Schema{
+					"access_policy": {
+						Type:       pluginsdk.TypeList,
+						ConfigMode: pluginsdk.SchemaConfigModeAttr,
+						Optional:   true,
+						Computed:   true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"object_id": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+									Computed: true,
+								},
+								"group_display_name": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+								},
+								"service_principal_client_id": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+								},
+								"user_principal_name": {
+									Type:     pluginsdk.TypeString,
+									Optional: true,
+								},
+							},
+						},
+					},
+				},
+				CustomizeDiff: keyVaultAccessPoliciesPrincipalCustomizeDiff,
+			},
+		},
+	}
+	// Microsoft Graph isn't available, so resolving a principal fails - but after checking for conflicts
+	provider.SetMeta(&clients.Client{
+		KeyVault: keyVaultClient.NewClient(&common.ClientOptions{}),
+	})
+	server := schema.NewGRPCProviderServer(provider)
+
+	schemaResp, err := server.GetProviderSchema(context.TODO(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+	objectType := schemaResp.ResourceSchemas["validator_key_vault"].ValueType().(tftypes.Object)
+	accessPoliciesType := objectType.AttributeTypes["access_policy"].(tftypes.List)
+	accessPolicyType := accessPoliciesType.ElementType.(tftypes.Object)
+
+	value := func(objectId interface{}) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "example"),
+			"access_policy": tftypes.NewValue(accessPoliciesType, []tftypes.Value{
+				tftypes.NewValue(accessPolicyType, map[string]tftypes.Value{
+					"object_id":                   tftypes.NewValue(tftypes.String, objectId),
+					"group_display_name":          tftypes.NewValue(tftypes.String, "Admins"),
+					"service_principal_client_id": tftypes.NewValue(tftypes.String, nil),
+					"user_principal_name":         tftypes.NewValue(tftypes.String, nil),
+				}),
+			}),
+		})
+	}
+	dynamicValue := func(input tftypes.Value) *tfprotov5.DynamicValue {
+		v, err := tfprotov5.NewDynamicValue(objectType, input)
+		if err != nil {
+			t.Fatalf("building DynamicValue: %+v", err)
+		}
+		return &v
+	}
+	plan := func(prior, config tftypes.Value) string {
+		resp, err := server.PlanResourceChange(context.TODO(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "validator_key_vault",
+			PriorState:       dynamicValue(prior),
+			ProposedNewState: dynamicValue(prior),
+			Config:           dynamicValue(config),
+		})
+		if err != nil {
+			t.Fatalf("planning: %+v", err)
+		}
+		for _, v := range resp.Diagnostics {
+			if v.Severity == tfprotov5.DiagnosticSeverityError {
+				return v.Summary
+			}
+		}
+		return ""
+	}
+
+	objectId := "00000000-0000-0000-0000-000000000001"
+
+	t.Log("specifying both the `object_id` and `group_display_name`..")
+	if err := plan(value(objectId), value(objectId)); !strings.Contains(err, "only one of `object_id` or `group_display_name` can be specified") {
+		t.Fatalf("expected an error for the conflicting fields but got %q", err)
+	}
+
+	t.Log("using the Computed `object_id` from the prior state..")
+	// the principal is then resolved, which fails since Microsoft Graph isn't available
+	if err := plan(value(objectId), value(nil)); !strings.Contains(err, "resolving the Object ID for `group_display_name`") {
+		t.Fatalf("expected no conflict when the `object_id` is only in the prior state but got %q", err)
+	}
+}
@@ -171,33 +171,46 @@ func schemaStoragePermissions() *pluginsdk.Schema {
 	}
 }
 
-func expandAccessPolicies(input []interface{}) *[]keyvault.AccessPolicyEntry {
+func expandAccessPolicies(input []KeyVaultAccessPolicyModel) *[]keyvault.AccessPolicyEntry {
 	output := make([]keyvault.AccessPolicyEntry, 0)
 
-	for _, policySet := range input {
-		policyRaw := policySet.(map[string]interface{})
+	for _, item := range input {
+		certificatePermissions := make([]keyvault.CertificatePermissions, 0)
+		for _, permission := range item.CertificatePermissions {
+			certificatePermissions = append(certificatePermissions, keyvault.CertificatePermissions(permission))
+		}
+
+		keyPermissions := make([]keyvault.KeyPermissions, 0)
+		for _, permission := range item.KeyPermissions {
+			keyPermissions = append(keyPermissions, keyvault.KeyPermissions(permission))
+		}
+
+		secretPermissions := make([]keyvault.SecretPermissions, 0)
+		for _, permission := range item.SecretPermissions {
+			secretPermissions = append(secretPermissions, keyvault.SecretPermissions(permission))
+		}
 
-		certificatePermissionsRaw := policyRaw["certificate_permissions"].([]interface{})
-		keyPermissionsRaw := policyRaw["key_permissions"].([]interface{})
-		secretPermissionsRaw := policyRaw["secret_permissions"].([]interface{})
-		storagePermissionsRaw := policyRaw["storage_permissions"].([]interface{})
+		storagePermissions := make([]keyvault.StoragePermissions, 0)
+		for _, permission := range item.StoragePermissions {
+			storagePermissions = append(storagePermissions, keyvault.StoragePermissions(permission))
+		}
 
 		policy := keyvault.AccessPolicyEntry{
 			Permissions: &keyvault.Permissions{
-				Certificates: expandCertificatePermissions(certificatePermissionsRaw),
-				Keys:         expandKeyPermissions(keyPermissionsRaw),
-				Secrets:      expandSecretPermissions(secretPermissionsRaw),
-				Storage:      expandStoragePermissions(storagePermissionsRaw),
+				Certificates: &certificatePermissions,
+				Keys:         &keyPermissions,
+				Secrets:      &secretPermissions,
+				Storage:      &storagePermissions,
 			},
 		}
 
-		tenantUUID := uuid.FromStringOrNil(policyRaw["tenant_id"].(string))
+		tenantUUID := uuid.FromStringOrNil(item.TenantId)
 		policy.TenantID = &tenantUUID
-		objectUUID := policyRaw["object_id"].(string)
-		policy.ObjectID = &objectUUID
+		objectId := item.ObjectId
+		policy.ObjectID = &objectId
 
-		if v := policyRaw["application_id"]; v != "" {
-			applicationUUID := uuid.FromStringOrNil(v.(string))
+		if item.ApplicationId != "" {
+			applicationUUID := uuid.FromStringOrNil(item.ApplicationId)
 			policy.ApplicationID = &applicationUUID
 		}
 
@@ -207,43 +220,60 @@ func expandAccessPolicies(input []interface{}) *[]keyvault.AccessPolicyEntry {
 	return &output
 }
 
-func flattenAccessPolicies(policies *[]keyvault.AccessPolicyEntry) []map[string]interface{} {
-	result := make([]map[string]interface{}, 0)
+func flattenAccessPolicies(policies *[]keyvault.AccessPolicyEntry) []KeyVaultAccessPolicyModel {
+	result := make([]KeyVaultAccessPolicyModel, 0)
 
 	if policies == nil {
 		return result
 	}
 
 	for _, policy := range *policies {
-		policyRaw := make(map[string]interface{})
+		output := KeyVaultAccessPolicyModel{
+			CertificatePermissions: make([]string, 0),
+			KeyPermissions:         make([]string, 0),
+			SecretPermissions:      make([]string, 0),
+			StoragePermissions:     make([]string, 0),
+		}
 
 		if tenantId := policy.TenantID; tenantId != nil {
-			policyRaw["tenant_id"] = tenantId.String()
+			output.TenantId = tenantId.String()
 		}
 
 		if objectId := policy.ObjectID; objectId != nil {
-			policyRaw["object_id"] = *objectId
+			output.ObjectId = *objectId
 		}
 
 		if appId := policy.ApplicationID; appId != nil {
-			policyRaw["application_id"] = appId.String()
+			output.ApplicationId = appId.String()
 		}
 
 		if permissions := policy.Permissions; permissions != nil {
-			certs := flattenCertificatePermissions(permissions.Certificates)
-			policyRaw["certificate_permissions"] = certs
-
-			keys := flattenKeyPermissions(permissions.Keys)
-			policyRaw["key_permissions"] = keys
-
-			secrets := flattenSecretPermissions(permissions.Secrets)
-			policyRaw["secret_permissions"] = secrets
-
-			storage := flattenStoragePermissions(permissions.Storage)
-			policyRaw["storage_permissions"] = storage
+			if permissions.Certificates != nil {
+				for _, permission := range *permissions.Certificates {
+					output.CertificatePermissions = append(output.CertificatePermissions, flattenCertificatePermission(string(permission)))
+				}
+			}
+
+			if permissions.Keys != nil {
+				for _, permission := range *permissions.Keys {
+					output.KeyPermissions = append(output.KeyPermissions, flattenKeyPermission(string(permission)))
+				}
+			}
+
+			if permissions.Secrets != nil {
+				for _, permission := range *permissions.Secrets {
+					output.SecretPermissions = append(output.SecretPermissions, flattenSecretPermission(string(permission)))
+				}
+			}
+
+			if permissions.Storage != nil {
+				for _, permission := range *permissions.Storage {
+					output.StoragePermissions = append(output.StoragePermissions, flattenStoragePermission(string(permission)))
+				}
+			}
 		}
 
-		result = append(result, policyRaw)
+		result = append(result, output)
 	}
 
 	return result
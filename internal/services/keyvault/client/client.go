@@ -11,6 +11,10 @@ type Client struct {
 	ManagementClient *keyvaultmgmt.BaseClient
 	VaultsClient     *keyvault.VaultsClient
 	options          *common.ClientOptions
+
+	// principalObjectIds caches the Object IDs resolved from Microsoft Graph - this is specific to this Client
+	// (rather than global) since each Provider block can authenticate against a different Tenant/Environment
+	principalObjectIds *principalObjectIdsCache
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -28,6 +32,8 @@ func NewClient(o *common.ClientOptions) *Client {
 		ManagementClient: &managementClient,
 		VaultsClient:     &vaultsClient,
 		options:          o,
+
+		principalObjectIds: newPrincipalObjectIdsCache(),
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	resourcesClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -180,20 +181,57 @@ func (c *Client) cacheKeyForKeyVault(name string) string {
 }
 
 func (c *Client) parseNameFromBaseUrl(input string) (*string, error) {
+	// the Domain Suffixes for the data plane vary by environment, for example:
+	// https://the-keyvault.vault.azure.net
+	// https://the-keyvault.vault.usgovcloudapi.net
+	// https://the-keyvault.vault.azure.cn
+	// https://the-hsm.managedhsm.azure.net
+	domainSuffixes := c.dataPlaneDomainSuffixes()
+	if len(domainSuffixes) > 0 {
+		return parse.KeyVaultNameFromBaseUrl(input, domainSuffixes)
+	}
+
+	// when the environment doesn't define a Domain Suffix, fall back to the conventional `{name}.vault.**` format
 	uri, err := url.Parse(input)
 	if err != nil {
 		return nil, err
 	}
 
-	// https://the-keyvault.vault.azure.net
-	// https://the-keyvault.vault.microsoftazure.de
-	// https://the-keyvault.vault.usgovcloudapi.net
-	// https://the-keyvault.vault.cloudapi.microsoft
-	// https://the-keyvault.vault.azure.cn
-
-	segments := strings.Split(uri.Host, ".")
+	segments := strings.Split(uri.Hostname(), ".")
 	if len(segments) < 3 || segments[1] != "vault" {
 		return nil, fmt.Errorf("expected a URI in the format `the-keyvault-name.vault.**` but got %q", uri.Host)
 	}
 	return &segments[0], nil
 }
+
+// ParseNestedItemID parses a versioned Key Vault Nested Item ID, validating that the Key Vault is within
+// one of the data plane Domain Suffixes for the active environment
+func (c *Client) ParseNestedItemID(input string) (*parse.NestedItemId, error) {
+	return parse.ParseNestedItemIDWithDomainSuffixes(input, c.dataPlaneDomainSuffixes())
+}
+
+// ParseOptionallyVersionedNestedItemID parses an optionally versioned Key Vault Nested Item ID, validating
+// that the Key Vault is within one of the data plane Domain Suffixes for the active environment
+func (c *Client) ParseOptionallyVersionedNestedItemID(input string) (*parse.NestedItemId, error) {
+	return parse.ParseOptionallyVersionedNestedItemIDWithDomainSuffixes(input, c.dataPlaneDomainSuffixes())
+}
+
+// dataPlaneDomainSuffixes returns the Domain Suffixes for the Key Vault and Managed HSM data planes
+// within the active environment, so that custom and sovereign clouds are supported
+func (c *Client) dataPlaneDomainSuffixes() []string {
+	domainSuffixes := make([]string, 0)
+	if c.options == nil {
+		return domainSuffixes
+	}
+
+	for _, api := range []environments.Api{c.options.Environment.KeyVault, c.options.Environment.ManagedHSM} {
+		if api == nil {
+			continue
+		}
+		if v, ok := api.DomainSuffix(); ok && v != nil && *v != "" {
+			domainSuffixes = append(domainSuffixes, *v)
+		}
+	}
+
+	return domainSuffixes
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+func TestDataPlaneDomainSuffixes(t *testing.T) {
+	testData := []struct {
+		name     string
+		options  *common.ClientOptions
+		expected []string
+	}{
+		{
+			name:     "no options",
+			options:  nil,
+			expected: []string{},
+		},
+		{
+			name: "public",
+			options: &common.ClientOptions{
+				Environment: *environments.AzurePublic(),
+			},
+			expected: []string{"vault.azure.net", "managedhsm.azure.net"},
+		},
+		{
+			// Managed HSM isn't available in Azure China
+			name: "china",
+			options: &common.ClientOptions{
+				Environment: *environments.AzureChina(),
+			},
+			expected: []string{"vault.azure.cn"},
+		},
+		{
+			name: "custom",
+			options: &common.ClientOptions{
+				Environment: environments.Environment{
+					KeyVault: environments.KeyVaultAPI("vault.contoso.internal"),
+				},
+			},
+			expected: []string{"vault.contoso.internal"},
+		},
+		{
+			name: "custom without a domain suffix",
+			options: &common.ClientOptions{
+				Environment: environments.Environment{
+					KeyVault: environments.KeyVaultAPI(""),
+				},
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		client := Client{
+			options: v.options,
+		}
+		actual := client.dataPlaneDomainSuffixes()
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestParseNestedItemIDUsesEnvironmentDomainSuffixes(t *testing.T) {
+	client := Client{
+		options: &common.ClientOptions{
+			Environment: *environments.AzureChina(),
+		},
+	}
+
+	if _, err := client.ParseNestedItemID("https://my-keyvault.vault.azure.cn/keys/castle/1492"); err != nil {
+		t.Fatalf("expected no error for a Key Vault within the environment but got: %+v", err)
+	}
+	if _, err := client.ParseNestedItemID("https://my-keyvault.vault.azure.net/keys/castle/1492"); err == nil {
+		t.Fatalf("expected an error for a Key Vault outside of the environment")
+	}
+
+	if _, err := client.ParseOptionallyVersionedNestedItemID("https://my-keyvault.vault.azure.cn/keys/castle"); err != nil {
+		t.Fatalf("expected no error for a Key Vault within the environment but got: %+v", err)
+	}
+	if _, err := client.ParseOptionallyVersionedNestedItemID("https://my-keyvault.vault.azure.net/keys/castle"); err == nil {
+		t.Fatalf("expected an error for a Key Vault outside of the environment")
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
+)
+
+type PrincipalType string
+
+const (
+	PrincipalTypeGroup            PrincipalType = "Group"
+	PrincipalTypeServicePrincipal PrincipalType = "ServicePrincipal"
+	PrincipalTypeUser             PrincipalType = "User"
+)
+
+// principalObjectIdsCache caches the Object IDs resolved from Microsoft Graph, so that each principal
+// is only looked up once per run regardless of how many Access Policies reference it
+type principalObjectIdsCache struct {
+	// lock is only held whilst reading from/writing to the cache (rather than during the lookup)
+	// so that lookups for different principals can happen concurrently
+	lock      *sync.RWMutex
+	objectIds map[string]string
+}
+
+func newPrincipalObjectIdsCache() *principalObjectIdsCache {
+	return &principalObjectIdsCache{
+		lock:      &sync.RWMutex{},
+		objectIds: map[string]string{},
+	}
+}
+
+func (c *principalObjectIdsCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	v, ok := c.objectIds[key]
+	return v, ok
+}
+
+func (c *principalObjectIdsCache) set(key, objectId string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.objectIds[key] = objectId
+}
+
+// ObjectIdForPrincipal resolves the Object ID for the User, Group or Service Principal identified by the
+// User Principal Name, Display Name or Client ID (respectively) using Microsoft Graph
+func (c *Client) ObjectIdForPrincipal(ctx context.Context, principalType PrincipalType, value string) (*string, error) {
+	cacheKey := fmt.Sprintf("%s/%s", principalType, strings.ToLower(value))
+
+	if v, ok := c.principalObjectIds.get(cacheKey); ok {
+		return &v, nil
+	}
+
+	authorizer, err := c.graphAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	var description string
+	switch principalType {
+	case PrincipalTypeGroup:
+		description = fmt.Sprintf("Groups with the Display Name %q", value)
+		ids, err = graph.GroupObjectIDs(ctx, authorizer, c.options.Environment, value)
+	case PrincipalTypeServicePrincipal:
+		description = fmt.Sprintf("Service Principals with the Client ID %q", value)
+		ids, err = graph.ServicePrincipalObjectIDs(ctx, authorizer, c.options.Environment, value)
+	case PrincipalTypeUser:
+		description = fmt.Sprintf("Users with the User Principal Name %q", value)
+		ids, err = graph.UserObjectIDs(ctx, authorizer, c.options.Environment, value)
+	default:
+		return nil, fmt.Errorf("unsupported Principal Type %q", string(principalType))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %+v", description, err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no %s were found", description)
+	case 1:
+		c.principalObjectIds.set(cacheKey, ids[0])
+		return &ids[0], nil
+	default:
+		return nil, fmt.Errorf("found %d %s (Object IDs: %s) but expected a single match - please specify the `object_id` instead", len(ids), description, strings.Join(ids, ", "))
+	}
+}
+
+// GroupIdsForPrincipal returns the Object IDs of each Group which the specified principal is a (direct or transitive) member of
+func (c *Client) GroupIdsForPrincipal(ctx context.Context, objectId string) ([]string, error) {
+	authorizer, err := c.graphAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := graph.MemberGroupIDs(ctx, authorizer, c.options.Environment, objectId)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Group memberships for the Object ID %q: %+v", objectId, err)
+	}
+
+	return ids, nil
+}
+
+func (c *Client) graphAuthorizer() (auth.Authorizer, error) {
+	if c.options == nil || c.options.Authorizers == nil || c.options.Authorizers.AuthorizerFunc == nil {
+		return nil, fmt.Errorf("an authorizer for Microsoft Graph is not available")
+	}
+
+	authorizer, err := c.options.Authorizers.AuthorizerFunc(c.options.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, fmt.Errorf("building authorizer for Microsoft Graph: %+v", err)
+	}
+
+	return authorizer, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+func TestObjectIdForPrincipalCacheIsPerClient(t *testing.T) {
+	// the Clients have no authorizer, so any lookup which isn't served from the cache fails
+	first := Client{
+		options: &common.ClientOptions{
+			Environment: *environments.AzurePublic(),
+		},
+		principalObjectIds: newPrincipalObjectIdsCache(),
+	}
+	second := Client{
+		options: &common.ClientOptions{
+			Environment: *environments.AzurePublic(),
+		},
+		principalObjectIds: newPrincipalObjectIdsCache(),
+	}
+	first.principalObjectIds.set("Group/admins", "00000000-0000-0000-0000-000000000001")
+
+	objectId, err := first.ObjectIdForPrincipal(context.TODO(), PrincipalTypeGroup, "Admins")
+	if err != nil {
+		t.Fatalf("expected the Object ID to be returned from the cache but got: %+v", err)
+	}
+	if *objectId != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("expected the cached Object ID but got %q", *objectId)
+	}
+
+	if _, err := second.ObjectIdForPrincipal(context.TODO(), PrincipalTypeGroup, "Admins"); err == nil {
+		t.Fatalf("expected the Object ID cached by another Client (which may be for a different Tenant) not to be used")
+	}
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -84,7 +83,7 @@ func (EncryptedValueDataSource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("only one of `encrypted_data` or `plain_text_value` must be specified - both were specified")
 			}
 
-			keyVaultKeyId, err := parse.ParseNestedItemID(model.KeyVaultKeyId)
+			keyVaultKeyId, err := metadata.Client.KeyVault.ParseNestedItemID(model.KeyVaultKeyId)
 			if err != nil {
 				return err
 			}
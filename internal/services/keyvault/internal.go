@@ -4,13 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -109,33 +107,11 @@ func deleteAndOptionallyPurge(ctx context.Context, description string, shouldPur
 	return nil
 }
 
-func keyVaultChildItemRefreshFunc(secretUri string) pluginsdk.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Checking to see if KeyVault Secret %q is available..", secretUri)
-
-		PTransport := &http.Transport{Proxy: http.ProxyFromEnvironment}
-
-		client := &http.Client{
-			Transport: PTransport,
-		}
-
-		conn, err := client.Get(secretUri)
-		if err != nil {
-			log.Printf("[DEBUG] Didn't find KeyVault secret at %q", secretUri)
-			return nil, "pending", fmt.Errorf("checking secret at %q: %s", secretUri, err)
-		}
-
-		defer conn.Body.Close()
-
-		log.Printf("[DEBUG] Found KeyVault Secret %q", secretUri)
-		return "available", "available", nil
-	}
-}
-
 func nestedItemResourceImporter(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
 	resourcesClient := meta.(*clients.Client).Resource
-	id, err := parse.ParseNestedItemID(d.Id())
+	// the Domain Suffix of the Base URL is validated against the active environment
+	id, err := keyVaultsClient.ParseNestedItemID(d.Id())
 	if err != nil {
 		return []*pluginsdk.ResourceData{d}, fmt.Errorf("parsing ID %q for Key Vault Child import: %v", d.Id(), err)
 	}
@@ -144,6 +120,9 @@ func nestedItemResourceImporter(ctx context.Context, d *pluginsdk.ResourceData,
 	if err != nil {
 		return []*pluginsdk.ResourceData{d}, fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
 	}
+	if keyVaultId == nil {
+		return []*pluginsdk.ResourceData{d}, fmt.Errorf("unable to determine the Resource ID for the Key Vault at URL %q", id.KeyVaultBaseUrl)
+	}
 	d.Set("key_vault_id", keyVaultId)
 
 	return []*pluginsdk.ResourceData{d}, nil
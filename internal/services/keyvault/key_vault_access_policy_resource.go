@@ -39,6 +39,8 @@ func resourceKeyVaultAccessPolicy() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(keyVaultAccessPolicyPrincipalCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"key_vault_id": {
 				Type:         pluginsdk.TypeString,
@@ -56,9 +58,32 @@ func resourceKeyVaultAccessPolicy() *pluginsdk.Resource {
 
 			"object_id": {
 				Type:         pluginsdk.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"object_id", "user_principal_name", "group_display_name", "service_principal_client_id"},
+			},
+
+			"user_principal_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"object_id", "user_principal_name", "group_display_name", "service_principal_client_id"},
+			},
+
+			"group_display_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"object_id", "user_principal_name", "group_display_name", "service_principal_client_id"},
+			},
+
+			"service_principal_client_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"object_id", "user_principal_name", "group_display_name", "service_principal_client_id"},
 			},
 
 			"application_id": {
@@ -97,6 +122,21 @@ func resourceKeyVaultAccessPolicyCreateOrDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	objectId := d.Get("object_id").(string)
+	if objectId == "" {
+		// the principal couldn't be resolved at plan time since it wasn't known
+		resolvedObjectId, err := resolveAccessPolicyObjectId(ctx, meta.(*clients.Client).KeyVault, map[string]interface{}{
+			"group_display_name":          d.Get("group_display_name"),
+			"service_principal_client_id": d.Get("service_principal_client_id"),
+			"user_principal_name":         d.Get("user_principal_name"),
+		})
+		if err != nil {
+			return err
+		}
+		if resolvedObjectId == nil {
+			return fmt.Errorf("one of `object_id`, `user_principal_name`, `group_display_name` or `service_principal_client_id` must be specified")
+		}
+		objectId = *resolvedObjectId
+	}
 	applicationIdRaw := d.Get("application_id").(string)
 
 	id := parse.NewAccessPolicyId(*vaultId, objectId, applicationIdRaw)
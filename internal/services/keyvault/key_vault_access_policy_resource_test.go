@@ -114,6 +114,22 @@ func TestAccKeyVaultAccessPolicy_update(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultAccessPolicy_servicePrincipalClientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_access_policy", "test")
+	r := KeyVaultAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.servicePrincipalClientId(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("object_id").MatchesOtherKey(check.That("data.azurerm_client_config.current").Key("object_id")),
+			),
+		},
+		data.ImportStep("service_principal_client_id"),
+	})
+}
+
 func TestAccKeyVaultAccessPolicy_nonExistentVault(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_access_policy", "test")
 	r := KeyVaultAccessPolicyResource{}
@@ -263,6 +279,33 @@ resource "azurerm_key_vault_access_policy" "test_no_application_id" {
 `, template)
 }
 
+func (r KeyVaultAccessPolicyResource) servicePrincipalClientId(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_access_policy" "test" {
+  key_vault_id = azurerm_key_vault.test.id
+
+  key_permissions = [
+    "Get",
+  ]
+
+  secret_permissions = [
+    "Get",
+    "Set",
+  ]
+
+  tenant_id                   = data.azurerm_client_config.current.tenant_id
+  service_principal_client_id = data.azurerm_client_config.current.client_id
+}
+`, template)
+}
+
 func (r KeyVaultAccessPolicyResource) nonExistentVault(data acceptance.TestData) string {
 	template := r.template(data)
 	return fmt.Sprintf(`
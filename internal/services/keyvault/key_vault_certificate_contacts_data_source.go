@@ -0,0 +1,106 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ sdk.DataSource = KeyVaultCertificateContactsDataSource{}
+
+type KeyVaultCertificateContactsDataSource struct{}
+
+func (KeyVaultCertificateContactsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VaultID,
+		},
+	}
+}
+
+func (KeyVaultCertificateContactsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"contact": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"email": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"phone": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (KeyVaultCertificateContactsDataSource) ModelObject() interface{} {
+	return &KeyVaultCertificateContactsResourceModel{}
+}
+
+func (KeyVaultCertificateContactsDataSource) ResourceType() string {
+	return "azurerm_key_vault_certificate_contacts"
+}
+
+func (KeyVaultCertificateContactsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			vaultClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var state KeyVaultCertificateContactsResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := parse.VaultID(state.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := vaultClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Base URI for Certificate Contacts in %s: %+v", *keyVaultId, err)
+			}
+
+			id, err := parse.NewCertificateContactsID(*keyVaultBaseUri)
+			if err != nil {
+				return err
+			}
+
+			// a Key Vault without any Certificate Contacts returns a 404, which is surfaced as an empty list
+			existing, err := client.GetCertificateContacts(ctx, id.KeyVaultBaseUrl)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+			}
+
+			state.KeyVaultId = keyVaultId.ID()
+			state.Contact = flattenKeyVaultCertificateContactsContact(existing.ContactList)
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
@@ -0,0 +1,64 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultCertificateContactsDataSource struct{}
+
+func TestAccKeyVaultCertificateContactsDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_certificate_contacts", "test")
+	r := KeyVaultCertificateContactsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("contact.#").HasValue("1"),
+				check.That(data.ResourceName).Key("contact.0.email").HasValue("example@example.com"),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultCertificateContactsDataSource_empty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_certificate_contacts", "test")
+	r := KeyVaultCertificateContactsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.empty(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("contact.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (KeyVaultCertificateContactsDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_certificate_contacts" "test" {
+  key_vault_id = azurerm_key_vault_certificate_contacts.test.key_vault_id
+}
+`, KeyVaultCertificateContactsResource{}.basic(data))
+}
+
+func (KeyVaultCertificateContactsDataSource) empty(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_certificate_contacts" "test" {
+  key_vault_id = azurerm_key_vault.test.id
+
+  depends_on = [
+    azurerm_key_vault_access_policy.test
+  ]
+}
+`, KeyVaultCertificateContactsResource{}.template(data))
+}
@@ -0,0 +1,210 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ sdk.DataSource = KeyVaultCertificateIssuersDataSource{}
+
+type KeyVaultCertificateIssuersDataSource struct{}
+
+type KeyVaultCertificateIssuersDataSourceModel struct {
+	KeyVaultId    string                           `tfschema:"key_vault_id"`
+	IncludeAdmins bool                             `tfschema:"include_admins"`
+	Issuers       []KeyVaultCertificateIssuerModel `tfschema:"issuers"`
+}
+
+type KeyVaultCertificateIssuerModel struct {
+	Id           string                                `tfschema:"id"`
+	Name         string                                `tfschema:"name"`
+	ProviderName string                                `tfschema:"provider_name"`
+	OrgId        string                                `tfschema:"org_id"`
+	Admin        []KeyVaultCertificateIssuerAdminModel `tfschema:"admin"`
+}
+
+type KeyVaultCertificateIssuerAdminModel struct {
+	EmailAddress string `tfschema:"email_address"`
+	FirstName    string `tfschema:"first_name"`
+	LastName     string `tfschema:"last_name"`
+	Phone        string `tfschema:"phone"`
+}
+
+func (KeyVaultCertificateIssuersDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VaultID,
+		},
+
+		"include_admins": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (KeyVaultCertificateIssuersDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"issuers": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"provider_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"org_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"admin": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"email_address": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"first_name": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"last_name": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"phone": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (KeyVaultCertificateIssuersDataSource) ModelObject() interface{} {
+	return &KeyVaultCertificateIssuersDataSourceModel{}
+}
+
+func (KeyVaultCertificateIssuersDataSource) ResourceType() string {
+	return "azurerm_key_vault_certificate_issuers"
+}
+
+func (KeyVaultCertificateIssuersDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultCertificateIssuersDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keyVaultId, err := parse.VaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Base URI for Certificate Issuers in %s: %+v", *keyVaultId, err)
+			}
+
+			iterator, err := client.GetCertificateIssuersComplete(ctx, *keyVaultBaseUri, utils.Int32(25))
+			if err != nil {
+				return fmt.Errorf("listing Certificate Issuers in %s: %+v", *keyVaultId, err)
+			}
+
+			model.Issuers = make([]KeyVaultCertificateIssuerModel, 0)
+			for iterator.NotDone() {
+				item := iterator.Value()
+				if item.ID != nil {
+					issuer, err := keyVaultCertificateIssuerFromItem(ctx, client, item, model.IncludeAdmins)
+					if err != nil {
+						return fmt.Errorf("retrieving Certificate Issuer in %s: %+v", *keyVaultId, err)
+					}
+					model.Issuers = append(model.Issuers, *issuer)
+				}
+
+				if err := iterator.NextWithContext(ctx); err != nil {
+					return fmt.Errorf("listing Certificate Issuers in %s: %+v", *keyVaultId, err)
+				}
+			}
+
+			metadata.SetID(keyVaultId)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// keyVaultCertificateIssuerFromItem retrieves the Organization Details for the specified Certificate Issuer, since
+// these aren't returned when listing - notably the Credentials are never exposed, as these contain the password
+func keyVaultCertificateIssuerFromItem(ctx context.Context, client *keyvault.BaseClient, item keyvault.CertificateIssuerItem, includeAdmins bool) (*KeyVaultCertificateIssuerModel, error) {
+	id, err := parse.IssuerID(*item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetCertificateIssuer(ctx, id.KeyVaultBaseUrl, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Certificate Issuer %q: %+v", id.Name, err)
+	}
+
+	issuer := KeyVaultCertificateIssuerModel{
+		Id:           *item.ID,
+		Name:         id.Name,
+		ProviderName: utils.NormalizeNilableString(item.Provider),
+		Admin:        make([]KeyVaultCertificateIssuerAdminModel, 0),
+	}
+
+	if details := resp.OrganizationDetails; details != nil {
+		issuer.OrgId = utils.NormalizeNilableString(details.ID)
+
+		if includeAdmins && details.AdminDetails != nil {
+			for _, admin := range *details.AdminDetails {
+				issuer.Admin = append(issuer.Admin, KeyVaultCertificateIssuerAdminModel{
+					EmailAddress: utils.NormalizeNilableString(admin.EmailAddress),
+					FirstName:    utils.NormalizeNilableString(admin.FirstName),
+					LastName:     utils.NormalizeNilableString(admin.LastName),
+					Phone:        utils.NormalizeNilableString(admin.Phone),
+				})
+			}
+		}
+	}
+
+	return &issuer, nil
+}
@@ -0,0 +1,73 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultCertificateIssuersDataSource struct{}
+
+func TestAccKeyVaultCertificateIssuersDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_certificate_issuers", "test")
+	r := KeyVaultCertificateIssuersDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("issuers.#").HasValue("1"),
+				check.That(data.ResourceName).Key("issuers.0.name").HasValue(fmt.Sprintf("acctestKVCI-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("issuers.0.provider_name").HasValue("DigiCert"),
+				check.That(data.ResourceName).Key("issuers.0.org_id").HasValue("accTestOrg"),
+				check.That(data.ResourceName).Key("issuers.0.admin.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultCertificateIssuersDataSource_includeAdmins(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_certificate_issuers", "test")
+	r := KeyVaultCertificateIssuersDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.includeAdmins(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("issuers.#").HasValue("1"),
+				check.That(data.ResourceName).Key("issuers.0.admin.#").HasValue("1"),
+				check.That(data.ResourceName).Key("issuers.0.admin.0.email_address").HasValue("admin@contoso.com"),
+				check.That(data.ResourceName).Key("issuers.0.admin.0.first_name").HasValue("First"),
+				check.That(data.ResourceName).Key("issuers.0.admin.0.last_name").HasValue("Last"),
+				check.That(data.ResourceName).Key("issuers.0.admin.0.phone").HasValue("01234567890"),
+			),
+		},
+	})
+}
+
+func (KeyVaultCertificateIssuersDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_certificate_issuers" "test" {
+  key_vault_id = azurerm_key_vault.test.id
+
+  depends_on = [azurerm_key_vault_certificate_issuer.test]
+}
+`, KeyVaultCertificateIssuerResource{}.complete(data))
+}
+
+func (KeyVaultCertificateIssuersDataSource) includeAdmins(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_certificate_issuers" "test" {
+  key_vault_id   = azurerm_key_vault.test.id
+  include_admins = true
+
+  depends_on = [azurerm_key_vault_certificate_issuer.test]
+}
+`, KeyVaultCertificateIssuerResource{}.complete(data))
+}
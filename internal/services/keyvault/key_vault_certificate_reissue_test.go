@@ -0,0 +1,125 @@
+package keyvault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestKeyVaultCertificateReissueCustomizeDiff(t *testing.T) {
+	versionSpecificAttributes := []string{"version", "secret_id", "certificate_data", "certificate_data_base64", "thumbprint", "resource_manager_id"}
+
+	resource := &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"certificate_policy": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"validity_in_months": {
+							Type:     pluginsdk.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"reissue_on_policy_change": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"certificate_attribute": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"expires": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: keyVaultCertificateReissueCustomizeDiff,
+	}
+	for _, key := range versionSpecificAttributes {
+		resource.Schema[key] = &pluginsdk.Schema{
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		}
+	}
+
+	state := &terraform.InstanceState{
+		ID: "https://example.vault.azure.net/certificates/example/first",
+		Attributes: map[string]string{
+			"id":                   "https://example.vault.azure.net/certificates/example/first",
+			"certificate_policy.#": "1",
+			"certificate_policy.0.validity_in_months": "12",
+			"certificate_attribute.#":                 "1",
+			"certificate_attribute.0.expires":         "2024-01-01T00:00:00Z",
+		},
+	}
+	for _, key := range versionSpecificAttributes {
+		state.Attributes[key] = "first"
+	}
+
+	testData := []struct {
+		name             string
+		reissue          bool
+		validityInMonths int
+		expectComputed   bool
+	}{
+		{
+			name:             "policy changed and reissued",
+			reissue:          true,
+			validityInMonths: 24,
+			expectComputed:   true,
+		},
+		{
+			name:             "policy changed but not reissued",
+			reissue:          false,
+			validityInMonths: 24,
+			expectComputed:   false,
+		},
+		{
+			name:             "policy unchanged",
+			reissue:          true,
+			validityInMonths: 12,
+			expectComputed:   false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		state.Attributes["reissue_on_policy_change"] = "false"
+		if v.reissue {
+			state.Attributes["reissue_on_policy_change"] = "true"
+		}
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"reissue_on_policy_change": v.reissue,
+			"certificate_policy": []interface{}{
+				map[string]interface{}{
+					"validity_in_months": v.validityInMonths,
+				},
+			},
+		})
+
+		diff, err := resource.Diff(context.TODO(), state, config, nil)
+		if err != nil {
+			t.Fatalf("computing the diff: %+v", err)
+		}
+
+		for _, key := range append(versionSpecificAttributes, "certificate_attribute.#") {
+			computed := diff != nil && diff.Attributes[key] != nil && diff.Attributes[key].NewComputed
+			if computed != v.expectComputed {
+				t.Fatalf("expected `%s` to be computed to be %t but got %t", key, v.expectComputed, computed)
+			}
+		}
+	}
+}
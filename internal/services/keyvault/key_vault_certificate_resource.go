@@ -28,7 +28,6 @@ import (
 
 func resourceKeyVaultCertificate() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
-		// TODO: support Updating additional properties once we have more information about what can be updated
 		Create: resourceKeyVaultCertificateCreate,
 		Read:   resourceKeyVaultCertificateRead,
 		Delete: resourceKeyVaultCertificateDelete,
@@ -37,7 +36,7 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
 			_, err := parse.ParseNestedItemID(id)
 			return err
-		}, nestedItemResourceImporter),
+		}, keyVaultCertificateResourceImporter),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
@@ -47,6 +46,15 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			nestedItemPolicyCustomizeDiff(
+				nestedItemPolicyValidityInMonths,
+				nestedItemPolicyRequiredTags,
+				nestedItemPolicyCertificateKey,
+			),
+			keyVaultCertificateReissueCustomizeDiff,
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -91,7 +99,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 				AtLeastOneOf: []string{
 					"certificate_policy",
 					"certificate",
@@ -108,7 +115,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 									"name": {
 										Type:     pluginsdk.TypeString,
 										Required: true,
-										ForceNew: true,
 									},
 								},
 							},
@@ -184,7 +190,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 												"action_type": {
 													Type:     pluginsdk.TypeString,
 													Required: true,
-													ForceNew: true,
 													ValidateFunc: validation.StringInSlice([]string{
 														string(keyvault.CertificatePolicyActionAutoRenew),
 														string(keyvault.CertificatePolicyActionEmailContacts),
@@ -203,12 +208,10 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 												"days_before_expiry": {
 													Type:     pluginsdk.TypeInt,
 													Optional: true,
-													ForceNew: true,
 												},
 												"lifetime_percentage": {
 													Type:     pluginsdk.TypeInt,
 													Optional: true,
-													ForceNew: true,
 												},
 											},
 										},
@@ -242,7 +245,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 										Type:     pluginsdk.TypeList,
 										Optional: true,
 										Computed: true,
-										ForceNew: true,
 										Elem: &pluginsdk.Schema{
 											Type:         pluginsdk.TypeString,
 											ValidateFunc: validation.StringIsNotEmpty,
@@ -251,7 +253,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 									"key_usage": {
 										Type:     pluginsdk.TypeSet,
 										Required: true,
-										ForceNew: true,
 										Elem: &pluginsdk.Schema{
 											Type: pluginsdk.TypeString,
 											ValidateFunc: validation.StringInSlice([]string{
@@ -270,12 +271,10 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 									"subject": {
 										Type:     pluginsdk.TypeString,
 										Required: true,
-										ForceNew: true,
 									},
 									"subject_alternative_names": {
 										Type:     pluginsdk.TypeList,
 										Optional: true,
-										ForceNew: true,
 										Computed: true,
 										MaxItems: 1,
 										Elem: &pluginsdk.Resource{
@@ -283,7 +282,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 												"emails": {
 													Type:     pluginsdk.TypeSet,
 													Optional: true,
-													ForceNew: true,
 													Elem: &pluginsdk.Schema{
 														Type: pluginsdk.TypeString,
 													},
@@ -297,7 +295,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 												"dns_names": {
 													Type:     pluginsdk.TypeSet,
 													Optional: true,
-													ForceNew: true,
 													Elem: &pluginsdk.Schema{
 														Type: pluginsdk.TypeString,
 													},
@@ -311,7 +308,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 												"upns": {
 													Type:     pluginsdk.TypeSet,
 													Optional: true,
-													ForceNew: true,
 													Elem: &pluginsdk.Schema{
 														Type: pluginsdk.TypeString,
 													},
@@ -328,7 +324,6 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 									"validity_in_months": {
 										Type:     pluginsdk.TypeInt,
 										Required: true,
-										ForceNew: true,
 									},
 								},
 							},
@@ -337,6 +332,15 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 				},
 			},
 
+			"reissue_on_policy_change": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+				ConflictsWith: []string{
+					"certificate",
+				},
+			},
+
 			// Computed
 			"certificate_attribute": {
 				Type:     pluginsdk.TypeList,
@@ -421,6 +425,39 @@ func resourceKeyVaultCertificate() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"pending_operation": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"status": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"status_details": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"request_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"error_code": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"error_message": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"tags": tags.Schema(),
 		},
 	}
@@ -494,24 +531,8 @@ func resourceKeyVaultCertificateCreate(d *pluginsdk.ResourceData, meta interface
 			}
 		}
 
-		log.Printf("[DEBUG] Waiting for Key Vault Certificate %q in Vault %q to be provisioned", name, *keyVaultBaseUrl)
-		stateConf := &pluginsdk.StateChangeConf{
-			Pending:    []string{"Provisioning"},
-			Target:     []string{"Ready"},
-			Refresh:    keyVaultCertificateCreationRefreshFunc(ctx, client, *keyVaultBaseUrl, name),
-			MinTimeout: 15 * time.Second,
-			Timeout:    d.Timeout(pluginsdk.TimeoutCreate),
-		}
-		// It has been observed that at least one certificate issuer responds to a request with manual processing by issuer staff. SLA's may differ among issuers.
-		// The total create timeout duration is divided by a modified poll interval of 30s to calculate the number of times to allow not found instead of the default 20.
-		// Using math.Floor, the calculation will err on the lower side of the creation timeout, so as to return before the overall create timeout occurs.
-		if policy != nil && policy.IssuerParameters != nil && policy.IssuerParameters.Name != nil && *policy.IssuerParameters.Name != "Self" {
-			stateConf.PollInterval = 30 * time.Second
-			stateConf.NotFoundChecks = int(math.Floor(float64(stateConf.Timeout) / float64(stateConf.PollInterval)))
-		}
-
-		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
-			return fmt.Errorf("waiting for Certificate %q in Vault %q to become available: %s", name, *keyVaultBaseUrl, err)
+		if err := waitForKeyVaultCertificateIssuance(ctx, meta, policy, *keyVaultBaseUrl, name, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
+			return err
 		}
 	}
 
@@ -540,17 +561,7 @@ func recoverDeletedCertificate(ctx context.Context, d *pluginsdk.ResourceData, m
 	}
 	log.Printf("[DEBUG] Recovering Secret %q with ID: %q", name, *recoveredCertificate.ID)
 	if certificate := recoveredCertificate.ID; certificate != nil {
-		stateConf := &pluginsdk.StateChangeConf{
-			Pending:                   []string{"pending"},
-			Target:                    []string{"available"},
-			Refresh:                   keyVaultChildItemRefreshFunc(*certificate),
-			Delay:                     30 * time.Second,
-			PollInterval:              10 * time.Second,
-			ContinuousTargetOccurence: 10,
-			Timeout:                   d.Timeout(pluginsdk.TimeoutCreate),
-		}
-
-		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		if err := waitForKeyVaultReadiness(ctx, meta.(*clients.Client), *certificate, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
 			return fmt.Errorf("waiting for Key Vault Secret %q to become available: %s", name, err)
 		}
 		log.Printf("[DEBUG] Secret %q recovered with ID: %q", name, *recoveredCertificate.ID)
@@ -558,9 +569,16 @@ func recoverDeletedCertificate(ctx context.Context, d *pluginsdk.ResourceData, m
 	return nil
 }
 
+func keyVaultCertificateResourceImporter(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+	// this isn't returned from the API, so is defaulted to match a newly created Certificate
+	d.Set("reissue_on_policy_change", false)
+
+	return nestedItemResourceImporter(ctx, d, meta)
+}
+
 func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
 	id, err := parse.ParseNestedItemID(d.Id())
@@ -593,6 +611,48 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if d.HasChange("certificate_policy") {
+		policy, err := expandKeyVaultCertificatePolicy(d)
+		if err != nil {
+			return fmt.Errorf("expanding certificate policy: %s", err)
+		}
+
+		if d.Get("reissue_on_policy_change").(bool) {
+			// creating a Certificate which already exists issues a new version of it using the specified policy
+			parameters := keyvault.CertificateCreateParameters{
+				CertificatePolicy: policy,
+				Tags:              tags.Expand(d.Get("tags").(map[string]interface{})),
+			}
+			if _, err := client.CreateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, parameters); err != nil {
+				return fmt.Errorf("issuing a new version of %s: %+v", *id, err)
+			}
+
+			if err := waitForKeyVaultCertificateIssuance(ctx, meta, policy, id.KeyVaultBaseUrl, id.Name, d.Timeout(pluginsdk.TimeoutUpdate)); err != nil {
+				return err
+			}
+
+			resp, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
+			if err != nil {
+				return fmt.Errorf("retrieving the new version of %s: %+v", *id, err)
+			}
+			if resp.ID == nil {
+				return fmt.Errorf("retrieving the new version of %s: `id` was nil", *id)
+			}
+
+			newId, err := parse.ParseNestedItemID(*resp.ID)
+			if err != nil {
+				return err
+			}
+			id = newId
+			d.SetId(id.ID())
+		} else if policy != nil {
+			// the updated policy is used when the next version of the Certificate is issued
+			if _, err := client.UpdateCertificatePolicy(ctx, id.KeyVaultBaseUrl, id.Name, *policy); err != nil {
+				return fmt.Errorf("updating the Certificate Policy for %s: %+v", *id, err)
+			}
+		}
+	}
+
 	if d.HasChange("tags") {
 		patch := keyvault.CertificateUpdateParameters{}
 		if t, ok := d.GetOk("tags"); ok {
@@ -606,6 +666,62 @@ func resourceKeyVaultCertificateUpdate(d *schema.ResourceData, meta interface{})
 	return resourceKeyVaultCertificateRead(d, meta)
 }
 
+// keyVaultCertificateReissueCustomizeDiff marks the version specific attributes as computed when the Certificate
+// Policy changes and `reissue_on_policy_change` is enabled, since reissuing the Certificate creates a new version
+func keyVaultCertificateReissueCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("certificate_policy") || !d.Get("reissue_on_policy_change").(bool) {
+		return nil
+	}
+
+	for _, key := range []string{"version", "secret_id", "certificate_data", "certificate_data_base64", "thumbprint", "certificate_attribute", "resource_manager_id"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return fmt.Errorf("setting `%s` to computed: %+v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// waitForKeyVaultCertificateIssuance waits for the pending Certificate Operation for the latest version of the
+// Certificate to complete - and cancels it when this fails, if enabled within the `features` block
+func waitForKeyVaultCertificateIssuance(ctx context.Context, meta interface{}, policy *keyvault.CertificatePolicy, keyVaultBaseUrl string, name string, timeout time.Duration) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+
+	log.Printf("[DEBUG] Waiting for Key Vault Certificate %q in Vault %q to be provisioned", name, keyVaultBaseUrl)
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:    []string{"Provisioning"},
+		Target:     []string{"Ready"},
+		Refresh:    keyVaultCertificateCreationRefreshFunc(ctx, client, keyVaultBaseUrl, name),
+		MinTimeout: 15 * time.Second,
+		Timeout:    timeout,
+	}
+	// It has been observed that at least one certificate issuer responds to a request with manual processing by issuer staff. SLA's may differ among issuers.
+	// The total create timeout duration is divided by a modified poll interval of 30s to calculate the number of times to allow not found instead of the default 20.
+	// Using math.Floor, the calculation will err on the lower side of the creation timeout, so as to return before the overall create timeout occurs.
+	if policy != nil && policy.IssuerParameters != nil && policy.IssuerParameters.Name != nil && *policy.IssuerParameters.Name != "Self" {
+		stateConf.PollInterval = 30 * time.Second
+		stateConf.NotFoundChecks = int(math.Floor(float64(stateConf.Timeout) / float64(stateConf.PollInterval)))
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		waitErr := fmt.Errorf("waiting for Certificate %q in Vault %q to become available: %s", name, keyVaultBaseUrl, err)
+
+		if meta.(*clients.Client).Features.KeyVault.CancelPendingCertificateOperationsOnFailure {
+			// the context may have expired at this point, so the clean up needs its own
+			cancelCtx, cancelCancel := context.WithTimeout(meta.(*clients.Client).StopContext, 5*time.Minute)
+			defer cancelCancel()
+
+			if err := cancelPendingCertificateOperation(cancelCtx, client, keyVaultBaseUrl, name); err != nil {
+				return fmt.Errorf("%+v\n\nadditionally, cancelling the pending Certificate Operation: %+v", waitErr, err)
+			}
+		}
+
+		return waitErr
+	}
+
+	return nil
+}
+
 func keyVaultCertificateCreationRefreshFunc(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUrl string, name string) pluginsdk.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		res, err := client.GetCertificate(ctx, keyVaultBaseUrl, name, "")
@@ -621,6 +737,19 @@ func keyVaultCertificateCreationRefreshFunc(ctx context.Context, client *keyvaul
 		}
 
 		if res.Sid == nil || *res.Sid == "" {
+			// when the Issuer has rejected the request there's no point waiting until the timeout
+			operation, err := client.GetCertificateOperation(ctx, keyVaultBaseUrl, name)
+			if err != nil {
+				if utils.ResponseWasNotFound(operation.Response) {
+					return nil, "Provisioning", nil
+				}
+				return nil, "", fmt.Errorf("retrieving Certificate Operation for Certificate %q in Vault %q: %s", name, keyVaultBaseUrl, err)
+			}
+
+			if status := operation.Status; status != nil && (strings.EqualFold(*status, "failed") || strings.EqualFold(*status, "cancelled")) {
+				return nil, "", fmt.Errorf("the Certificate Operation for Certificate %q in Vault %q is %q: %s", name, keyVaultBaseUrl, *status, certificateOperationErrorMessage(operation))
+			}
+
 			return nil, "Provisioning", nil
 		}
 
@@ -628,6 +757,49 @@ func keyVaultCertificateCreationRefreshFunc(ctx context.Context, client *keyvaul
 	}
 }
 
+// cancelPendingCertificateOperation requests cancellation of any in-progress Certificate Operation and then
+// deletes it - since otherwise the pending operation blocks the Certificate from being created again
+func cancelPendingCertificateOperation(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUrl string, name string) error {
+	operation, err := client.GetCertificateOperation(ctx, keyVaultBaseUrl, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(operation.Response) {
+			return nil
+		}
+		return fmt.Errorf("retrieving Certificate Operation for Certificate %q in Vault %q: %+v", name, keyVaultBaseUrl, err)
+	}
+
+	if operation.Status != nil && strings.EqualFold(*operation.Status, "completed") {
+		return nil
+	}
+
+	if operation.Status != nil && strings.EqualFold(*operation.Status, "inProgress") {
+		log.Printf("[DEBUG] Cancelling the pending Certificate Operation for Certificate %q in Vault %q", name, keyVaultBaseUrl)
+		parameters := keyvault.CertificateOperationUpdateParameter{
+			CancellationRequested: utils.Bool(true),
+		}
+		if _, err := client.UpdateCertificateOperation(ctx, keyVaultBaseUrl, name, parameters); err != nil {
+			return fmt.Errorf("requesting cancellation of the Certificate Operation for Certificate %q in Vault %q: %+v", name, keyVaultBaseUrl, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Deleting the pending Certificate Operation for Certificate %q in Vault %q", name, keyVaultBaseUrl)
+	if resp, err := client.DeleteCertificateOperation(ctx, keyVaultBaseUrl, name); err != nil {
+		if !utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("deleting the Certificate Operation for Certificate %q in Vault %q: %+v", name, keyVaultBaseUrl, err)
+		}
+	}
+
+	return nil
+}
+
+func certificateOperationErrorMessage(input keyvault.CertificateOperation) string {
+	if input.Error != nil {
+		return fmt.Sprintf("%s: %s", utils.NormalizeNilableString(input.Error.Code), utils.NormalizeNilableString(input.Error.Message))
+	}
+
+	return utils.NormalizeNilableString(input.StatusDetails)
+}
+
 func resourceKeyVaultCertificateRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
 	client := meta.(*clients.Client).KeyVault.ManagementClient
@@ -726,9 +898,41 @@ func resourceKeyVaultCertificateRead(d *pluginsdk.ResourceData, meta interface{}
 	}
 	d.Set("thumbprint", thumbprint)
 
+	operation, err := client.GetCertificateOperation(ctx, id.KeyVaultBaseUrl, id.Name)
+	if err != nil && !utils.ResponseWasNotFound(operation.Response) {
+		return fmt.Errorf("retrieving Certificate Operation for Certificate %q in Key Vault at URI %q: %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+	if err := d.Set("pending_operation", flattenKeyVaultCertificatePendingOperation(operation)); err != nil {
+		return fmt.Errorf("setting `pending_operation`: %+v", err)
+	}
+
 	return tags.FlattenAndSet(d, cert.Tags)
 }
 
+func flattenKeyVaultCertificatePendingOperation(input keyvault.CertificateOperation) []interface{} {
+	// a completed operation isn't pending, and imported certificates don't have an operation at all
+	if input.Status == nil || strings.EqualFold(*input.Status, "completed") {
+		return []interface{}{}
+	}
+
+	errorCode := ""
+	errorMessage := ""
+	if input.Error != nil {
+		errorCode = utils.NormalizeNilableString(input.Error.Code)
+		errorMessage = utils.NormalizeNilableString(input.Error.Message)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"status":         *input.Status,
+			"status_details": utils.NormalizeNilableString(input.StatusDetails),
+			"request_id":     utils.NormalizeNilableString(input.RequestID),
+			"error_code":     errorCode,
+			"error_message":  errorMessage,
+		},
+	}
+}
+
 func resourceKeyVaultCertificateDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	keyVaultsClient := meta.(*clients.Client).KeyVault
 	client := meta.(*clients.Client).KeyVault.ManagementClient
@@ -98,6 +98,7 @@ func TestAccKeyVaultCertificate_basicGenerate(t *testing.T) {
 				check.That(data.ResourceName).Key("certificate_data_base64").Exists(),
 				check.That(data.ResourceName).Key("thumbprint").Exists(),
 				check.That(data.ResourceName).Key("certificate_attribute.0.created").Exists(),
+				check.That(data.ResourceName).Key("pending_operation.#").HasValue("0"),
 			),
 		},
 		data.ImportStep(),
@@ -113,6 +114,9 @@ func TestAccKeyVaultCertificate_basicGenerateUnknownIssuer(t *testing.T) {
 			Config: r.basicGenerateUnknownIssuer(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				// certificates from an Unknown issuer remain pending until they're merged
+				check.That(data.ResourceName).Key("pending_operation.0.status").HasValue("inProgress"),
+				check.That(data.ResourceName).Key("pending_operation.0.request_id").Exists(),
 			),
 		},
 		data.ImportStep(),
@@ -205,6 +209,54 @@ func TestAccKeyVaultCertificate_updateTags(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultCertificate_updatePolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicGenerateSans(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updatedPolicy(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_policy.0.lifetime_action.0.trigger.0.lifetime_percentage").HasValue("80"),
+				check.That(data.ResourceName).Key("certificate_policy.0.x509_certificate_properties.0.subject_alternative_names.0.dns_names.#").HasValue("2"),
+				check.That(data.ResourceName).Key("certificate_policy.0.x509_certificate_properties.0.validity_in_months").HasValue("24"),
+			),
+		},
+		data.ImportStep("reissue_on_policy_change"),
+	})
+}
+
+func TestAccKeyVaultCertificate_reissueOnPolicyChange(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
+	r := KeyVaultCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicGenerateSans(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.updatedPolicy(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_policy.0.x509_certificate_properties.0.subject_alternative_names.0.dns_names.#").HasValue("2"),
+				check.That(data.ResourceName).Key("certificate_data").Exists(),
+			),
+		},
+		data.ImportStep("reissue_on_policy_change"),
+	})
+}
+
 func TestAccKeyVaultCertificate_basicGenerateEllipticCurve(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_certificate", "test")
 	r := KeyVaultCertificateResource{}
@@ -752,6 +804,70 @@ resource "azurerm_key_vault_certificate" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r KeyVaultCertificateResource) updatedPolicy(data acceptance.TestData, reissue bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_certificate" "test" {
+  name                     = "acctestcert%s"
+  key_vault_id             = azurerm_key_vault.test.id
+  reissue_on_policy_change = %t
+
+  certificate_policy {
+    issuer_parameters {
+      name = "Self"
+    }
+
+    key_properties {
+      exportable = true
+      key_size   = 2048
+      key_type   = "RSA"
+      reuse_key  = true
+    }
+
+    lifetime_action {
+      action {
+        action_type = "AutoRenew"
+      }
+
+      trigger {
+        lifetime_percentage = 80
+      }
+    }
+
+    secret_properties {
+      content_type = "application/x-pkcs12"
+    }
+
+    x509_certificate_properties {
+      key_usage = [
+        "cRLSign",
+        "dataEncipherment",
+        "digitalSignature",
+        "keyAgreement",
+        "keyCertSign",
+        "keyEncipherment",
+      ]
+
+      subject = "CN=hello-world"
+
+      subject_alternative_names {
+        emails    = ["mary@stu.co.uk"]
+        dns_names = ["internal.contoso.com", "external.contoso.com"]
+        upns      = ["john@doe.com"]
+      }
+
+      validity_in_months = 24
+    }
+  }
+}
+`, r.template(data), data.RandomString, reissue)
+}
+
 func (r KeyVaultCertificateResource) basicGenerateTags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
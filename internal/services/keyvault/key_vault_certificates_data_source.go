@@ -1,132 +1,116 @@
 package keyvault
 
 import (
+	"context"
 	"fmt"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
 
-func dataSourceKeyVaultCertificates() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Read: dataSourceKeyVaultCertificatesRead,
+var _ sdk.DataSource = KeyVaultCertificatesDataSource{}
 
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+type KeyVaultCertificatesDataSource struct{}
+
+type KeyVaultCertificatesDataSourceModel struct {
+	KeyVaultId     string                    `tfschema:"key_vault_id"`
+	IncludePending bool                      `tfschema:"include_pending"`
+	Names          []string                  `tfschema:"names"`
+	Certificates   []KeyVaultNestedItemModel `tfschema:"certificates"`
+}
+
+func (KeyVaultCertificatesDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: keyVaultValidate.VaultID,
 		},
 
-		Schema: map[string]*pluginsdk.Schema{
-			"key_vault_id": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: keyVaultValidate.VaultID,
-			},
-
-			"names": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Schema{
-					Type: pluginsdk.TypeString,
-				},
-			},
-
-			"include_pending": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-				Default:  true,
-			},
-
-			"certificates": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"enabled": {
-							Type:     pluginsdk.TypeBool,
-							Computed: true,
-						},
-					},
-				},
-			},
+		"include_pending": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
 		},
 	}
 }
 
-func dataSourceKeyVaultCertificatesRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (KeyVaultCertificatesDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"names": keyVaultNestedItemNamesSchema(),
 
-	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
-	if err != nil {
-		return err
+		"certificates": keyVaultNestedItemsSchema(),
 	}
+}
 
-	includePending := d.Get("include_pending").(bool)
+func (KeyVaultCertificatesDataSource) ModelObject() interface{} {
+	return &KeyVaultCertificatesDataSourceModel{}
+}
 
-	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
-	if err != nil {
-		return fmt.Errorf("fetching base vault url from id %q: %+v", *keyVaultId, err)
-	}
+func (KeyVaultCertificatesDataSource) ResourceType() string {
+	return "azurerm_key_vault_certificates"
+}
 
-	certificateList, err := client.GetCertificatesComplete(ctx, *keyVaultBaseUri, utils.Int32(25), &includePending)
-	if err != nil {
-		return fmt.Errorf("retrieving %s: %+v", *keyVaultId, err)
-	}
+func (KeyVaultCertificatesDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultCertificatesDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	d.SetId(keyVaultId.ID())
+			keyVaultId, err := parse.VaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
 
-	var names []string
-	var certs []map[string]interface{}
-	if certificateList.Response().Value != nil {
-		for certificateList.NotDone() {
-			for _, v := range *certificateList.Response().Value {
-				nestedItem, err := parse.ParseOptionallyVersionedNestedItemID(*v.ID)
-				if err != nil {
-					return err
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Base URI for Certificates in %s: %+v", *keyVaultId, err)
+			}
+
+			iterator, err := client.GetCertificatesComplete(ctx, *keyVaultBaseUri, utils.Int32(25), utils.Bool(model.IncludePending))
+			if err != nil {
+				return fmt.Errorf("listing Certificates in %s: %+v", *keyVaultId, err)
+			}
+
+			model.Names, model.Certificates, err = listKeyVaultNestedItems[keyvault.CertificateItem](ctx, &iterator, func(item keyvault.CertificateItem) (*KeyVaultNestedItemModel, error) {
+				if item.ID == nil {
+					return nil, nil
 				}
-				names = append(names, nestedItem.Name)
-				certs = append(certs, expandCertificate(nestedItem.Name, v))
-				err = certificateList.NextWithContext(ctx)
+
+				nestedItemId, err := parse.ParseOptionallyVersionedNestedItemID(*item.ID)
 				if err != nil {
-					return fmt.Errorf("retrieving next page of Certificates from %s: %+v", *keyVaultId, err)
+					return nil, err
 				}
-			}
-		}
-	}
 
-	d.Set("names", names)
-	d.Set("certificates", certs)
-	d.Set("key_vault_id", keyVaultId.ID())
+				enabled := false
+				if item.Attributes != nil && item.Attributes.Enabled != nil {
+					enabled = *item.Attributes.Enabled
+				}
 
-	return nil
-}
+				return &KeyVaultNestedItemModel{
+					Id:      *item.ID,
+					Name:    nestedItemId.Name,
+					Enabled: enabled,
+				}, nil
+			})
+			if err != nil {
+				return fmt.Errorf("listing Certificates in %s: %+v", *keyVaultId, err)
+			}
 
-func expandCertificate(name string, item keyvault.CertificateItem) map[string]interface{} {
-	var cert = map[string]interface{}{
-		"name": name,
-		"id":   *item.ID,
-	}
-	if item.Attributes != nil && item.Attributes.Enabled != nil {
-		cert["enabled"] = *item.Attributes.Enabled
+			model.KeyVaultId = keyVaultId.ID()
+			metadata.SetID(keyVaultId)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
 	}
-	return cert
 }
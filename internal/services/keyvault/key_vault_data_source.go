@@ -1,241 +1,295 @@
 package keyvault
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2021-10-01/keyvault" // nolint: staticcheck
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/set"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-func dataSourceKeyVault() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Read: dataSourceKeyVaultRead,
+type KeyVaultDataSource struct{}
+
+var _ sdk.DataSourceWithNotFoundBehaviour = KeyVaultDataSource{}
+
+type KeyVaultDataSourceModel struct {
+	Name                         string                                `tfschema:"name"`
+	ResourceGroupName            string                                `tfschema:"resource_group_name"`
+	Location                     string                                `tfschema:"location"`
+	SkuName                      string                                `tfschema:"sku_name"`
+	VaultUri                     string                                `tfschema:"vault_uri"`
+	TenantId                     string                                `tfschema:"tenant_id"`
+	AccessPolicy                 []KeyVaultDataSourceAccessPolicyModel `tfschema:"access_policy"`
+	EnabledForDeployment         bool                                  `tfschema:"enabled_for_deployment"`
+	EnabledForDiskEncryption     bool                                  `tfschema:"enabled_for_disk_encryption"`
+	EnabledForTemplateDeployment bool                                  `tfschema:"enabled_for_template_deployment"`
+	EnableRbacAuthorization      bool                                  `tfschema:"enable_rbac_authorization"`
+	NetworkAcls                  []KeyVaultNetworkAclsModel            `tfschema:"network_acls"`
+	PurgeProtectionEnabled       bool                                  `tfschema:"purge_protection_enabled"`
+	PublicNetworkAccessEnabled   bool                                  `tfschema:"public_network_access_enabled"`
+	Tags                         map[string]string                     `tfschema:"tags"`
+}
 
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
-		},
+type KeyVaultDataSourceAccessPolicyModel struct {
+	TenantId               string   `tfschema:"tenant_id"`
+	ObjectId               string   `tfschema:"object_id"`
+	ApplicationId          string   `tfschema:"application_id"`
+	CertificatePermissions []string `tfschema:"certificate_permissions"`
+	KeyPermissions         []string `tfschema:"key_permissions"`
+	SecretPermissions      []string `tfschema:"secret_permissions"`
+	StoragePermissions     []string `tfschema:"storage_permissions"`
+}
 
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: validate.VaultName,
-			},
+func (KeyVaultDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VaultName,
+		},
 
-			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+	}
+}
 
-			"location": commonschema.LocationComputed(),
+func (KeyVaultDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"location": commonschema.LocationComputed(),
 
-			"sku_name": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"sku_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
 
-			"vault_uri": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"vault_uri": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
 
-			"tenant_id": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+		"tenant_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
 
-			"access_policy": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"tenant_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"object_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"application_id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"certificate_permissions": {
-							Type:     pluginsdk.TypeList,
-							Computed: true,
-							Elem: &pluginsdk.Schema{
-								Type: pluginsdk.TypeString,
-							},
+		"access_policy": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"tenant_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"object_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"application_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"certificate_permissions": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
 						},
-						"key_permissions": {
-							Type:     pluginsdk.TypeList,
-							Computed: true,
-							Elem: &pluginsdk.Schema{
-								Type: pluginsdk.TypeString,
-							},
+					},
+					"key_permissions": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
 						},
-						"secret_permissions": {
-							Type:     pluginsdk.TypeList,
-							Computed: true,
-							Elem: &pluginsdk.Schema{
-								Type: pluginsdk.TypeString,
-							},
+					},
+					"secret_permissions": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
 						},
-						"storage_permissions": {
-							Type:     pluginsdk.TypeList,
-							Computed: true,
-							Elem: &pluginsdk.Schema{
-								Type: pluginsdk.TypeString,
-							},
+					},
+					"storage_permissions": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
 						},
 					},
 				},
 			},
+		},
 
-			"enabled_for_deployment": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
+		"enabled_for_deployment": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
 
-			"enabled_for_disk_encryption": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
+		"enabled_for_disk_encryption": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
 
-			"enabled_for_template_deployment": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
+		"enabled_for_template_deployment": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
 
-			// TODO 4.0: change this from enable_* to *_enabled
-			"enable_rbac_authorization": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
+		// TODO 4.0: change this from enable_* to *_enabled
+		"enable_rbac_authorization": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
 
-			"network_acls": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"default_action": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"bypass": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-						"ip_rules": {
-							Type:     pluginsdk.TypeSet,
-							Computed: true,
-							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
-							Set:      pluginsdk.HashString,
-						},
-						"virtual_network_subnet_ids": {
-							Type:     pluginsdk.TypeSet,
-							Computed: true,
-							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
-							Set:      set.HashStringIgnoreCase,
-						},
+		"network_acls": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"default_action": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"bypass": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"ip_rules": {
+						Type:     pluginsdk.TypeSet,
+						Computed: true,
+						Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						Set:      pluginsdk.HashString,
+					},
+					"virtual_network_subnet_ids": {
+						Type:     pluginsdk.TypeSet,
+						Computed: true,
+						Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						Set:      set.HashStringIgnoreCase,
 					},
 				},
 			},
+		},
 
-			"purge_protection_enabled": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
-
-			"public_network_access_enabled": {
-				Type:     pluginsdk.TypeBool,
-				Computed: true,
-			},
+		"purge_protection_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
 
-			"tags": tags.SchemaDataSource(),
+		"public_network_access_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
 		},
+
+		"tags": tags.SchemaDataSource(),
 	}
 }
 
-func dataSourceKeyVaultRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).KeyVault.VaultsClient
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (KeyVaultDataSource) ModelObject() interface{} {
+	return &KeyVaultDataSourceModel{}
+}
 
-	id := parse.NewVaultID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+func (KeyVaultDataSource) ResourceType() string {
+	return keyVaultResourceName
+}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			return fmt.Errorf("KeyVault %s does not exist", id)
-		}
-		return fmt.Errorf("making read request %s: %+v", id, err)
-	}
+func (KeyVaultDataSource) DefaultNotFoundBehaviour() sdk.DataSourceNotFoundBehaviour {
+	return sdk.DataSourceNotFoundBehaviourError
+}
 
-	d.SetId(id.ID())
+func (KeyVaultDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.VaultsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
 
-	d.Set("name", id.Name)
-	d.Set("resource_group_name", id.ResourceGroup)
+			var model KeyVaultDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	d.Set("location", location.NormalizeNilable(resp.Location))
+			id := parse.NewVaultID(subscriptionId, model.ResourceGroupName, model.Name)
 
-	if props := resp.Properties; props != nil {
-		d.Set("tenant_id", props.TenantID.String())
-		d.Set("enabled_for_deployment", props.EnabledForDeployment)
-		d.Set("enabled_for_disk_encryption", props.EnabledForDiskEncryption)
-		d.Set("enabled_for_template_deployment", props.EnabledForTemplateDeployment)
-		d.Set("enable_rbac_authorization", props.EnableRbacAuthorization)
-		d.Set("purge_protection_enabled", props.EnablePurgeProtection)
-		if v := props.PublicNetworkAccess; v != nil {
-			d.Set("public_network_access_enabled", *v == "Enabled")
-		}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.NotFound(id)
+				}
+				return fmt.Errorf("making read request %s: %+v", id, err)
+			}
 
-		d.Set("vault_uri", props.VaultURI)
-		if props.VaultURI != nil {
-			meta.(*clients.Client).KeyVault.AddToCache(id, *resp.Properties.VaultURI)
-		}
+			metadata.SetID(id)
 
-		if sku := props.Sku; sku != nil {
-			if err := d.Set("sku_name", string(sku.Name)); err != nil {
-				return fmt.Errorf("setting `sku_name` for KeyVault %q: %+v", *resp.Name, err)
+			state := KeyVaultDataSourceModel{
+				Name:              id.Name,
+				ResourceGroupName: id.ResourceGroup,
+				Location:          location.NormalizeNilable(resp.Location),
+				AccessPolicy:      make([]KeyVaultDataSourceAccessPolicyModel, 0),
+				Tags:              tags.ToTypedObject(resp.Tags),
 			}
-		} else {
-			return fmt.Errorf("making Read request on KeyVault %q: Unable to retrieve 'sku' value", *resp.Name)
-		}
 
-		flattenedPolicies := flattenAccessPolicies(props.AccessPolicies)
-		if err := d.Set("access_policy", flattenedPolicies); err != nil {
-			return fmt.Errorf("setting `access_policy` for KeyVault %q: %+v", *resp.Name, err)
-		}
+			if props := resp.Properties; props != nil {
+				state.TenantId = props.TenantID.String()
+				state.EnabledForDeployment = utils.NormaliseNilableBool(props.EnabledForDeployment)
+				state.EnabledForDiskEncryption = utils.NormaliseNilableBool(props.EnabledForDiskEncryption)
+				state.EnabledForTemplateDeployment = utils.NormaliseNilableBool(props.EnabledForTemplateDeployment)
+				state.EnableRbacAuthorization = utils.NormaliseNilableBool(props.EnableRbacAuthorization)
+				state.PurgeProtectionEnabled = utils.NormaliseNilableBool(props.EnablePurgeProtection)
+				if v := props.PublicNetworkAccess; v != nil {
+					state.PublicNetworkAccessEnabled = *v == "Enabled"
+				}
+
+				if props.VaultURI != nil {
+					state.VaultUri = *props.VaultURI
+					metadata.Client.KeyVault.AddToCache(id, *props.VaultURI)
+				}
+
+				sku := props.Sku
+				if sku == nil {
+					return fmt.Errorf("making Read request on KeyVault %q: Unable to retrieve 'sku' value", *resp.Name)
+				}
+				state.SkuName = string(sku.Name)
+
+				for _, policy := range flattenAccessPolicies(props.AccessPolicies) {
+					state.AccessPolicy = append(state.AccessPolicy, KeyVaultDataSourceAccessPolicyModel{
+						TenantId:               policy.TenantId,
+						ObjectId:               policy.ObjectId,
+						ApplicationId:          policy.ApplicationId,
+						CertificatePermissions: policy.CertificatePermissions,
+						KeyPermissions:         policy.KeyPermissions,
+						SecretPermissions:      policy.SecretPermissions,
+						StoragePermissions:     policy.StoragePermissions,
+					})
+				}
+
+				state.NetworkAcls = flattenKeyVaultDataSourceNetworkAcls(props.NetworkAcls)
+			}
 
-		if err := d.Set("network_acls", flattenKeyVaultDataSourceNetworkAcls(props.NetworkAcls)); err != nil {
-			return fmt.Errorf("setting `network_acls` for KeyVault %q: %+v", *resp.Name, err)
-		}
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
 	}
-
-	return tags.FlattenAndSet(d, resp.Tags)
 }
 
-func flattenKeyVaultDataSourceNetworkAcls(input *keyvault.NetworkRuleSet) []interface{} {
+func flattenKeyVaultDataSourceNetworkAcls(input *keyvault.NetworkRuleSet) []KeyVaultNetworkAclsModel {
 	if input == nil {
-		return []interface{}{}
+		return []KeyVaultNetworkAclsModel{}
 	}
 
-	output := make(map[string]interface{})
-
-	output["bypass"] = string(input.Bypass)
-	output["default_action"] = string(input.DefaultAction)
+	output := KeyVaultNetworkAclsModel{
+		Bypass:        string(input.Bypass),
+		DefaultAction: string(input.DefaultAction),
+	}
 
-	ipRules := make([]interface{}, 0)
+	ipRules := make([]string, 0)
 	if input.IPRules != nil {
 		for _, v := range *input.IPRules {
 			if v.Value == nil {
@@ -245,9 +299,9 @@ func flattenKeyVaultDataSourceNetworkAcls(input *keyvault.NetworkRuleSet) []inte
 			ipRules = append(ipRules, *v.Value)
 		}
 	}
-	output["ip_rules"] = pluginsdk.NewSet(pluginsdk.HashString, ipRules)
+	output.IPRules = ipRules
 
-	virtualNetworkRules := make([]interface{}, 0)
+	virtualNetworkRules := make([]string, 0)
 	if input.VirtualNetworkRules != nil {
 		for _, v := range *input.VirtualNetworkRules {
 			if v.ID == nil {
@@ -257,7 +311,7 @@ func flattenKeyVaultDataSourceNetworkAcls(input *keyvault.NetworkRuleSet) []inte
 			virtualNetworkRules = append(virtualNetworkRules, *v.ID)
 		}
 	}
-	output["virtual_network_subnet_ids"] = pluginsdk.NewSet(pluginsdk.HashString, virtualNetworkRules)
+	output.VirtualNetworkSubnetIds = virtualNetworkRules
 
-	return []interface{}{output}
+	return []KeyVaultNetworkAclsModel{output}
 }
@@ -2,6 +2,7 @@ package keyvault_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -25,6 +26,7 @@ func TestAccDataSourceKeyVault_basic(t *testing.T) {
 				check.That(data.ResourceName).Key("access_policy.0.key_permissions.0").HasValue("Create"),
 				check.That(data.ResourceName).Key("access_policy.0.secret_permissions.0").HasValue("Set"),
 				check.That(data.ResourceName).Key("tags.%").HasValue("0"),
+				check.That(data.ResourceName).Key("exists").HasValue("true"),
 			),
 		},
 	})
@@ -95,6 +97,25 @@ func TestAccDataSourceKeyVault_update(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceKeyVault_notFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault", "test")
+	r := KeyVaultDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.notFound(data, "empty"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("exists").HasValue("false"),
+				check.That(data.ResourceName).Key("vault_uri").HasValue(""),
+			),
+		},
+		{
+			Config:      r.notFound(data, "error"),
+			ExpectError: regexp.MustCompile("was not found"),
+		},
+	})
+}
+
 func (KeyVaultDataSource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -138,3 +159,22 @@ data "azurerm_key_vault" "test" {
 }
 `, KeyVaultResource{}.networkAclsUpdated(data))
 }
+
+func (KeyVaultDataSource) notFound(data acceptance.TestData, behaviour string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+data "azurerm_key_vault" "test" {
+  name                = "vault%d"
+  resource_group_name = azurerm_resource_group.test.name
+  not_found_behaviour = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, behaviour)
+}
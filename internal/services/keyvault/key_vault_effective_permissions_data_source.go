@@ -0,0 +1,264 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2021-10-01/keyvault" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = KeyVaultEffectivePermissionsDataSource{}
+
+type KeyVaultEffectivePermissionsDataSource struct{}
+
+type KeyVaultEffectivePermissionsDataSourceModel struct {
+	KeyVaultId              string   `tfschema:"key_vault_id"`
+	ObjectId                string   `tfschema:"object_id"`
+	ApplicationId           string   `tfschema:"application_id"`
+	IncludeGroupMemberships bool     `tfschema:"include_group_memberships"`
+	CertificatePermissions  []string `tfschema:"certificate_permissions"`
+	KeyPermissions          []string `tfschema:"key_permissions"`
+	SecretPermissions       []string `tfschema:"secret_permissions"`
+	StoragePermissions      []string `tfschema:"storage_permissions"`
+	MatchedObjectIds        []string `tfschema:"matched_object_ids"`
+	EnableRbacAuthorization bool     `tfschema:"enable_rbac_authorization"`
+}
+
+func (KeyVaultEffectivePermissionsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.VaultID,
+		},
+
+		"object_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"application_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"include_group_memberships": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (KeyVaultEffectivePermissionsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"certificate_permissions": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"key_permissions": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"secret_permissions": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"storage_permissions": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"matched_object_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"enable_rbac_authorization": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultEffectivePermissionsDataSource) ModelObject() interface{} {
+	return &KeyVaultEffectivePermissionsDataSourceModel{}
+}
+
+func (KeyVaultEffectivePermissionsDataSource) ResourceType() string {
+	return "azurerm_key_vault_effective_permissions"
+}
+
+func (KeyVaultEffectivePermissionsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.VaultsClient
+
+			var model KeyVaultEffectivePermissionsDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			vaultId, err := parse.VaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
+
+			if vaultId.SubscriptionId != client.SubscriptionID {
+				client = keyVaultsClient.KeyVaultClientForSubscription(vaultId.SubscriptionId)
+			}
+
+			resp, err := client.Get(ctx, vaultId.ResourceGroup, vaultId.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *vaultId, err)
+			}
+			if resp.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *vaultId)
+			}
+
+			objectIds := []string{model.ObjectId}
+			if model.IncludeGroupMemberships {
+				groupIds, err := keyVaultsClient.GroupIdsForPrincipal(ctx, model.ObjectId)
+				if err != nil {
+					return err
+				}
+				objectIds = append(objectIds, groupIds...)
+			}
+
+			permissions := effectiveAccessPolicyPermissions(resp.Properties.AccessPolicies, objectIds, model.ApplicationId)
+			model.CertificatePermissions = permissions.certificates
+			model.KeyPermissions = permissions.keys
+			model.SecretPermissions = permissions.secrets
+			model.StoragePermissions = permissions.storage
+			model.MatchedObjectIds = permissions.matchedObjectIds
+
+			if v := resp.Properties.EnableRbacAuthorization; v != nil && *v {
+				log.Printf("[DEBUG] RBAC Authorization is enabled for %s - Access Policies are ignored when authorizing data-plane requests", *vaultId)
+				model.EnableRbacAuthorization = true
+			}
+
+			id := vaultId.ID() + "/objectId/" + model.ObjectId
+			if model.ApplicationId != "" {
+				id += "/applicationId/" + model.ApplicationId
+			}
+			metadata.ResourceData.SetId(id)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+type accessPolicyPermissions struct {
+	certificates     []string
+	keys             []string
+	secrets          []string
+	storage          []string
+	matchedObjectIds []string
+}
+
+// effectiveAccessPolicyPermissions returns the union of the permissions granted by each Access Policy assigned to one
+// of the specified Object IDs. Compound (application-specific) Access Policies are only included when they're assigned
+// to the specified `applicationId`, since these only apply when the principal is acting through that Application.
+func effectiveAccessPolicyPermissions(policies *[]keyvault.AccessPolicyEntry, objectIds []string, applicationId string) accessPolicyPermissions {
+	certificates := make(map[string]struct{})
+	keys := make(map[string]struct{})
+	secrets := make(map[string]struct{})
+	storage := make(map[string]struct{})
+	matched := make(map[string]struct{})
+
+	if policies != nil {
+		for _, policy := range *policies {
+			if policy.ObjectID == nil {
+				continue
+			}
+
+			objectIdMatches := false
+			for _, objectId := range objectIds {
+				if strings.EqualFold(*policy.ObjectID, objectId) {
+					objectIdMatches = true
+					break
+				}
+			}
+			if !objectIdMatches {
+				continue
+			}
+
+			// compound Access Policies only apply when the principal is acting through the Application
+			if policy.ApplicationID != nil && (applicationId == "" || !strings.EqualFold(policy.ApplicationID.String(), applicationId)) {
+				continue
+			}
+
+			matched[strings.ToLower(*policy.ObjectID)] = struct{}{}
+
+			if permissions := policy.Permissions; permissions != nil {
+				if permissions.Certificates != nil {
+					for _, v := range *permissions.Certificates {
+						certificates[flattenCertificatePermission(string(v))] = struct{}{}
+					}
+				}
+				if permissions.Keys != nil {
+					for _, v := range *permissions.Keys {
+						keys[flattenKeyPermission(string(v))] = struct{}{}
+					}
+				}
+				if permissions.Secrets != nil {
+					for _, v := range *permissions.Secrets {
+						secrets[flattenSecretPermission(string(v))] = struct{}{}
+					}
+				}
+				if permissions.Storage != nil {
+					for _, v := range *permissions.Storage {
+						storage[flattenStoragePermission(string(v))] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return accessPolicyPermissions{
+		certificates:     sortedKeys(certificates),
+		keys:             sortedKeys(keys),
+		secrets:          sortedKeys(secrets),
+		storage:          sortedKeys(storage),
+		matchedObjectIds: sortedKeys(matched),
+	}
+}
+
+func sortedKeys(input map[string]struct{}) []string {
+	output := make([]string, 0, len(input))
+	for k := range input {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
+}
@@ -0,0 +1,200 @@
+package keyvault
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2021-10-01/keyvault" // nolint: staticcheck
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestEffectiveAccessPolicyPermissions(t *testing.T) {
+	const (
+		userObjectId  = "00000000-0000-0000-0000-000000000001"
+		groupObjectId = "00000000-0000-0000-0000-000000000002"
+		otherObjectId = "00000000-0000-0000-0000-000000000003"
+		applicationId = "00000000-0000-0000-0000-00000000000a"
+	)
+	accessPolicy := func(objectId string, applicationId *string, keys []keyvault.KeyPermissions, secrets []keyvault.SecretPermissions) keyvault.AccessPolicyEntry {
+		policy := keyvault.AccessPolicyEntry{
+			ObjectID: utils.String(objectId),
+			Permissions: &keyvault.Permissions{
+				Keys:    &keys,
+				Secrets: &secrets,
+			},
+		}
+		if applicationId != nil {
+			id := uuid.FromStringOrNil(*applicationId)
+			policy.ApplicationID = &id
+		}
+		return policy
+	}
+
+	policies := []keyvault.AccessPolicyEntry{
+		accessPolicy(userObjectId, nil, []keyvault.KeyPermissions{"get", "list"}, nil),
+		accessPolicy(groupObjectId, nil, []keyvault.KeyPermissions{"Get", "sign"}, []keyvault.SecretPermissions{"get"}),
+		accessPolicy(otherObjectId, nil, []keyvault.KeyPermissions{"delete"}, []keyvault.SecretPermissions{"set"}),
+		// compound Access Policy, which only applies when acting through the Application
+		accessPolicy(userObjectId, utils.String(applicationId), []keyvault.KeyPermissions{"decrypt"}, []keyvault.SecretPermissions{"list"}),
+		{
+			// Access Policies without an Object ID are ignored
+			Permissions: &keyvault.Permissions{
+				Keys: &[]keyvault.KeyPermissions{"purge"},
+			},
+		},
+	}
+
+	testData := []struct {
+		name          string
+		policies      *[]keyvault.AccessPolicyEntry
+		objectIds     []string
+		applicationId string
+		expected      accessPolicyPermissions
+	}{
+		{
+			name:      "no access policies",
+			policies:  nil,
+			objectIds: []string{userObjectId},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{},
+			},
+		},
+		{
+			name:      "principal only",
+			policies:  &policies,
+			objectIds: []string{userObjectId},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Get", "List"},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{userObjectId},
+			},
+		},
+		{
+			name:      "object id in a different casing",
+			policies:  &policies,
+			objectIds: []string{"00000000-0000-0000-0000-00000000000A"},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{},
+			},
+		},
+		{
+			name: "object id in a different casing to the access policy",
+			policies: &[]keyvault.AccessPolicyEntry{
+				accessPolicy("AAAAAAAA-0000-0000-0000-000000000001", nil, []keyvault.KeyPermissions{"get"}, nil),
+			},
+			objectIds: []string{"aaaaaaaa-0000-0000-0000-000000000001"},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Get"},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{"aaaaaaaa-0000-0000-0000-000000000001"},
+			},
+		},
+		{
+			name:      "including group memberships",
+			policies:  &policies,
+			objectIds: []string{userObjectId, groupObjectId},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Get", "List", "Sign"},
+				secrets:          []string{"Get"},
+				storage:          []string{},
+				matchedObjectIds: []string{userObjectId, groupObjectId},
+			},
+		},
+		{
+			name:          "acting through the application",
+			policies:      &policies,
+			objectIds:     []string{userObjectId},
+			applicationId: applicationId,
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Decrypt", "Get", "List"},
+				secrets:          []string{"List"},
+				storage:          []string{},
+				matchedObjectIds: []string{userObjectId},
+			},
+		},
+		{
+			name:          "acting through the application in a different casing",
+			policies:      &policies,
+			objectIds:     []string{userObjectId},
+			applicationId: "00000000-0000-0000-0000-00000000000A",
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Decrypt", "Get", "List"},
+				secrets:          []string{"List"},
+				storage:          []string{},
+				matchedObjectIds: []string{userObjectId},
+			},
+		},
+		{
+			name:          "acting through a different application",
+			policies:      &policies,
+			objectIds:     []string{userObjectId},
+			applicationId: "00000000-0000-0000-0000-00000000000b",
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{"Get", "List"},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{userObjectId},
+			},
+		},
+		{
+			name: "only a compound access policy without an application",
+			policies: &[]keyvault.AccessPolicyEntry{
+				accessPolicy(userObjectId, utils.String(applicationId), []keyvault.KeyPermissions{"decrypt"}, nil),
+			},
+			objectIds: []string{userObjectId},
+			expected: accessPolicyPermissions{
+				certificates:     []string{},
+				keys:             []string{},
+				secrets:          []string{},
+				storage:          []string{},
+				matchedObjectIds: []string{},
+			},
+		},
+		{
+			name: "certificate and storage permissions are normalised",
+			policies: &[]keyvault.AccessPolicyEntry{
+				{
+					ObjectID: utils.String(userObjectId),
+					Permissions: &keyvault.Permissions{
+						Certificates: &[]keyvault.CertificatePermissions{"managecontacts", "Get", "get"},
+						Storage:      &[]keyvault.StoragePermissions{"regeneratekey", "getsas"},
+					},
+				},
+			},
+			objectIds: []string{userObjectId},
+			expected: accessPolicyPermissions{
+				certificates:     []string{"Get", "ManageContacts"},
+				keys:             []string{},
+				secrets:          []string{},
+				storage:          []string{"GetSAS", "RegenerateKey"},
+				matchedObjectIds: []string{userObjectId},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := effectiveAccessPolicyPermissions(v.policies, v.objectIds, v.applicationId)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
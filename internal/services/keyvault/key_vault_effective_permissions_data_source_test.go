@@ -0,0 +1,120 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultEffectivePermissionsDataSource struct{}
+
+func TestAccKeyVaultEffectivePermissionsDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_effective_permissions", "test")
+	r := KeyVaultEffectivePermissionsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("key_permissions.#").HasValue("2"),
+				check.That(data.ResourceName).Key("key_permissions.0").HasValue("Create"),
+				check.That(data.ResourceName).Key("key_permissions.1").HasValue("Get"),
+				check.That(data.ResourceName).Key("secret_permissions.#").HasValue("3"),
+				check.That(data.ResourceName).Key("certificate_permissions.#").HasValue("0"),
+				check.That(data.ResourceName).Key("matched_object_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("enable_rbac_authorization").HasValue("false"),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultEffectivePermissionsDataSource_applicationId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_effective_permissions", "test")
+	r := KeyVaultEffectivePermissionsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.applicationId(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("key_permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("secret_permissions.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func (r KeyVaultEffectivePermissionsDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_effective_permissions" "test" {
+  key_vault_id = azurerm_key_vault.test.id
+  object_id    = data.azurerm_client_config.current.object_id
+}
+`, r.template(data))
+}
+
+func (r KeyVaultEffectivePermissionsDataSource) applicationId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_effective_permissions" "test" {
+  key_vault_id   = azurerm_key_vault.test.id
+  object_id      = data.azurerm_client_config.current.object_id
+  application_id = "00000000-0000-0000-0000-000000000001"
+}
+`, r.template(data))
+}
+
+func (KeyVaultEffectivePermissionsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                = "acctestkv-%[3]s"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  tenant_id           = data.azurerm_client_config.current.tenant_id
+  sku_name            = "standard"
+
+  access_policy {
+    tenant_id = data.azurerm_client_config.current.tenant_id
+    object_id = data.azurerm_client_config.current.object_id
+
+    key_permissions = [
+      "Get",
+    ]
+
+    secret_permissions = [
+      "Get",
+      "Set",
+    ]
+  }
+
+  access_policy {
+    tenant_id      = data.azurerm_client_config.current.tenant_id
+    object_id      = data.azurerm_client_config.current.object_id
+    application_id = "00000000-0000-0000-0000-000000000002"
+
+    key_permissions = [
+      "Create",
+    ]
+
+    secret_permissions = [
+      "Delete",
+    ]
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,312 @@
+package keyvault
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ sdk.DataSource = KeyVaultJWKSDataSource{}
+
+type KeyVaultJWKSDataSource struct{}
+
+type KeyVaultJWKSDataSourceModel struct {
+	KeyIds             []string               `tfschema:"key_ids"`
+	IncludeAllVersions bool                   `tfschema:"include_all_versions"`
+	Keys               []KeyVaultJWKSKeyModel `tfschema:"keys"`
+	JSON               string                 `tfschema:"json"`
+}
+
+type KeyVaultJWKSKeyModel struct {
+	Kid string `tfschema:"kid"`
+	Kty string `tfschema:"kty"`
+	Alg string `tfschema:"alg"`
+	Use string `tfschema:"use"`
+}
+
+// jsonWebKey is a public JSON Web Key as defined in RFC 7517 - which (unlike the Key Vault representation)
+// only contains the Key Types and public components defined in RFC 7518
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+func (KeyVaultJWKSDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_ids": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validate.NestedItemIdWithOptionalVersion,
+			},
+		},
+
+		"include_all_versions": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (KeyVaultJWKSDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"keys": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"kid": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"kty": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"alg": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"use": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"json": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultJWKSDataSource) ModelObject() interface{} {
+	return &KeyVaultJWKSDataSourceModel{}
+}
+
+func (KeyVaultJWKSDataSource) ResourceType() string {
+	return "azurerm_key_vault_jwks"
+}
+
+func (KeyVaultJWKSDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultJWKSDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			keySet := jsonWebKeySet{
+				Keys: make([]jsonWebKey, 0),
+			}
+			kids := make(map[string]struct{})
+			for _, v := range model.KeyIds {
+				id, err := metadata.Client.KeyVault.ParseOptionallyVersionedNestedItemID(v)
+				if err != nil {
+					return err
+				}
+				if id.NestedItemType != "keys" {
+					return fmt.Errorf("expected %q to be the ID of a Key Vault Key but got a Nested Item Type of %q", v, id.NestedItemType)
+				}
+
+				versions := []string{id.Version}
+				if model.IncludeAllVersions {
+					if id.Version != "" {
+						return fmt.Errorf("`key_ids` must contain versionless Key IDs when `include_all_versions` is enabled but got %q", v)
+					}
+
+					versions, err = enabledKeyVaultKeyVersions(ctx, client, *id)
+					if err != nil {
+						return err
+					}
+				}
+
+				for _, version := range versions {
+					resp, err := client.GetKey(ctx, id.KeyVaultBaseUrl, id.Name, version)
+					if err != nil {
+						return fmt.Errorf("retrieving Key %q (Version %q) from Key Vault at URI %q: %+v", id.Name, version, id.KeyVaultBaseUrl, err)
+					}
+					if resp.Key == nil || resp.Key.Kid == nil {
+						return fmt.Errorf("retrieving Key %q (Version %q) from Key Vault at URI %q: `key` was nil", id.Name, version, id.KeyVaultBaseUrl)
+					}
+
+					if _, ok := kids[*resp.Key.Kid]; ok {
+						continue
+					}
+					kids[*resp.Key.Kid] = struct{}{}
+
+					jwk, err := jsonWebKeyFromKeyVaultKey(*resp.Key)
+					if err != nil {
+						return fmt.Errorf("building the JSON Web Key for %q: %+v", *resp.Key.Kid, err)
+					}
+					keySet.Keys = append(keySet.Keys, *jwk)
+				}
+			}
+
+			out, err := json.Marshal(keySet)
+			if err != nil {
+				return fmt.Errorf("serializing the JSON Web Key Set: %+v", err)
+			}
+			model.JSON = string(out)
+
+			model.Keys = make([]KeyVaultJWKSKeyModel, 0)
+			ids := make([]string, 0)
+			for _, key := range keySet.Keys {
+				model.Keys = append(model.Keys, KeyVaultJWKSKeyModel{
+					Kid: key.Kid,
+					Kty: key.Kty,
+					Alg: key.Alg,
+					Use: key.Use,
+				})
+				ids = append(ids, key.Kid)
+			}
+
+			metadata.ResourceData.SetId(fmt.Sprintf("jwks-%x", sha1.Sum([]byte(strings.Join(ids, ",")))))
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// enabledKeyVaultKeyVersions returns each of the enabled versions of the specified Key Vault Key
+func enabledKeyVaultKeyVersions(ctx context.Context, client *keyvault.BaseClient, id parse.NestedItemId) ([]string, error) {
+	versions := make([]string, 0)
+
+	iterator, err := client.GetKeyVersionsComplete(ctx, id.KeyVaultBaseUrl, id.Name, utils.Int32(25))
+	if err != nil {
+		return nil, fmt.Errorf("listing versions of Key %q in Key Vault at URI %q: %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+	for iterator.NotDone() {
+		item := iterator.Value()
+		if item.Kid != nil && (item.Attributes == nil || item.Attributes.Enabled == nil || *item.Attributes.Enabled) {
+			versionId, err := parse.ParseNestedItemID(*item.Kid)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, versionId.Version)
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing versions of Key %q in Key Vault at URI %q: %+v", id.Name, id.KeyVaultBaseUrl, err)
+		}
+	}
+
+	return versions, nil
+}
+
+// jsonWebKeyCurveNames maps the Curve names used by Key Vault to those registered for JSON Web Keys - which
+// differ for secp256k1, where Key Vault uses `P-256K` rather than `secp256k1` as registered in RFC 8812
+var jsonWebKeyCurveNames = map[keyvault.JSONWebKeyCurveName]string{
+	keyvault.JSONWebKeyCurveNameP256:  "P-256",
+	keyvault.JSONWebKeyCurveNameP256K: "secp256k1",
+	keyvault.JSONWebKeyCurveNameP384:  "P-384",
+	keyvault.JSONWebKeyCurveNameP521:  "P-521",
+}
+
+// jsonWebKeyFromKeyVaultKey converts a Key Vault Key into a public JSON Web Key, determining the `use` from the
+// Key Operations and the `alg` from the Key Type and Curve
+func jsonWebKeyFromKeyVaultKey(key keyvault.JSONWebKey) (*jsonWebKey, error) {
+	// parsing the public key ensures the key material is valid prior to publishing it
+	if _, err := publicKeyFromJSONWebKey(key); err != nil {
+		return nil, err
+	}
+
+	use := ""
+	if key.KeyOps != nil {
+		for _, op := range *key.KeyOps {
+			switch keyvault.JSONWebKeyOperation(op) {
+			case keyvault.JSONWebKeyOperationSign, keyvault.JSONWebKeyOperationVerify:
+				use = "sig"
+			case keyvault.JSONWebKeyOperationEncrypt, keyvault.JSONWebKeyOperationDecrypt, keyvault.JSONWebKeyOperationWrapKey, keyvault.JSONWebKeyOperationUnwrapKey:
+				if use == "" {
+					use = "enc"
+				}
+			}
+		}
+	}
+
+	if key.Kid == nil {
+		return nil, fmt.Errorf("`kid` was nil")
+	}
+	jwk := jsonWebKey{
+		Kid: *key.Kid,
+		Use: use,
+	}
+
+	switch key.Kty {
+	case keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeRSAHSM:
+		if key.N == nil || key.E == nil {
+			return nil, fmt.Errorf("`n` and `e` must be set for an RSA key")
+		}
+		jwk.Kty = "RSA"
+		jwk.N = *key.N
+		jwk.E = *key.E
+		switch use {
+		case "sig":
+			jwk.Alg = string(keyvault.JSONWebKeySignatureAlgorithmRS256)
+		case "enc":
+			jwk.Alg = string(keyvault.JSONWebKeyEncryptionAlgorithmRSAOAEP256)
+		}
+
+	case keyvault.JSONWebKeyTypeEC, keyvault.JSONWebKeyTypeECHSM:
+		if key.X == nil || key.Y == nil {
+			return nil, fmt.Errorf("`x` and `y` must be set for an EC key")
+		}
+		crv, ok := jsonWebKeyCurveNames[key.Crv]
+		if !ok {
+			return nil, fmt.Errorf("the Curve %q cannot be published as a public JSON Web Key", string(key.Crv))
+		}
+		jwk.Kty = "EC"
+		jwk.Crv = crv
+		jwk.X = *key.X
+		jwk.Y = *key.Y
+		if use == "sig" {
+			switch key.Crv {
+			case keyvault.JSONWebKeyCurveNameP256:
+				jwk.Alg = string(keyvault.JSONWebKeySignatureAlgorithmES256)
+			case keyvault.JSONWebKeyCurveNameP256K:
+				jwk.Alg = string(keyvault.JSONWebKeySignatureAlgorithmES256K)
+			case keyvault.JSONWebKeyCurveNameP384:
+				jwk.Alg = string(keyvault.JSONWebKeySignatureAlgorithmES384)
+			case keyvault.JSONWebKeyCurveNameP521:
+				jwk.Alg = string(keyvault.JSONWebKeySignatureAlgorithmES512)
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("the Key Type %q cannot be published as a public JSON Web Key", string(key.Kty))
+	}
+
+	return &jwk, nil
+}
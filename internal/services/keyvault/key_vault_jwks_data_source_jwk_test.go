@@ -0,0 +1,337 @@
+package keyvault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+const jsonWebKeyTestKid = "https://example.vault.azure.net/keys/example/abc123"
+
+func jsonWebKeyTestRSAKey(t *testing.T, keyOps ...string) keyvault.JSONWebKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating an RSA key: %+v", err)
+	}
+
+	return keyvault.JSONWebKey{
+		Kid:    utils.String(jsonWebKeyTestKid),
+		Kty:    keyvault.JSONWebKeyTypeRSA,
+		KeyOps: &keyOps,
+		N:      utils.String(base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes())),
+		E:      utils.String(base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes())),
+	}
+}
+
+func jsonWebKeyTestECKey(t *testing.T, curve keyvault.JSONWebKeyCurveName, keyOps ...string) keyvault.JSONWebKey {
+	// secp256k1 isn't supported by the standard library, however the key material is only parsed for the NIST curves
+	ellipticCurve := elliptic.P256()
+	switch curve {
+	case keyvault.JSONWebKeyCurveNameP384:
+		ellipticCurve = elliptic.P384()
+	case keyvault.JSONWebKeyCurveNameP521:
+		ellipticCurve = elliptic.P521()
+	}
+
+	privateKey, err := ecdsa.GenerateKey(ellipticCurve, rand.Reader)
+	if err != nil {
+		t.Fatalf("generating an EC key: %+v", err)
+	}
+
+	return keyvault.JSONWebKey{
+		Kid:    utils.String(jsonWebKeyTestKid),
+		Kty:    keyvault.JSONWebKeyTypeEC,
+		Crv:    curve,
+		KeyOps: &keyOps,
+		X:      utils.String(base64.RawURLEncoding.EncodeToString(privateKey.X.Bytes())),
+		Y:      utils.String(base64.RawURLEncoding.EncodeToString(privateKey.Y.Bytes())),
+	}
+}
+
+func TestJSONWebKeyFromKeyVaultKey(t *testing.T) {
+	withoutKid := jsonWebKeyTestRSAKey(t, "verify")
+	withoutKid.Kid = nil
+	withoutModulus := jsonWebKeyTestRSAKey(t, "verify")
+	withoutModulus.N = nil
+	withoutY := jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256, "verify")
+	withoutY.Y = nil
+	withInvalidX := jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256, "verify")
+	withInvalidX.X = utils.String("not base64!")
+	rsaHsm := jsonWebKeyTestRSAKey(t, "sign", "verify")
+	rsaHsm.Kty = keyvault.JSONWebKeyTypeRSAHSM
+	ecHsm := jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP384, "verify")
+	ecHsm.Kty = keyvault.JSONWebKeyTypeECHSM
+
+	testData := []struct {
+		name     string
+		input    keyvault.JSONWebKey
+		expected *jsonWebKey
+		error    bool
+	}{
+		{
+			name:  "RSA signing key",
+			input: jsonWebKeyTestRSAKey(t, "sign", "verify"),
+			expected: &jsonWebKey{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: "RS256",
+			},
+		},
+		{
+			name:  "RSA-HSM signing key",
+			input: rsaHsm,
+			expected: &jsonWebKey{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: "RS256",
+			},
+		},
+		{
+			name:  "RSA encryption key",
+			input: jsonWebKeyTestRSAKey(t, "encrypt", "decrypt", "wrapKey", "unwrapKey"),
+			expected: &jsonWebKey{
+				Kty: "RSA",
+				Use: "enc",
+				Alg: "RSA-OAEP-256",
+			},
+		},
+		{
+			// signing takes precedence, since the key can be used for both
+			name:  "RSA signing and encryption key",
+			input: jsonWebKeyTestRSAKey(t, "encrypt", "sign"),
+			expected: &jsonWebKey{
+				Kty: "RSA",
+				Use: "sig",
+				Alg: "RS256",
+			},
+		},
+		{
+			name:  "RSA key without key operations",
+			input: jsonWebKeyTestRSAKey(t),
+			expected: &jsonWebKey{
+				Kty: "RSA",
+			},
+		},
+		{
+			name:  "EC P-256 signing key",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256, "sign", "verify"),
+			expected: &jsonWebKey{
+				Kty: "EC",
+				Use: "sig",
+				Alg: "ES256",
+				Crv: "P-256",
+			},
+		},
+		{
+			name:  "EC P-256K signing key",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256K, "sign", "verify"),
+			expected: &jsonWebKey{
+				Kty: "EC",
+				Use: "sig",
+				Alg: "ES256K",
+				Crv: "secp256k1",
+			},
+		},
+		{
+			name:  "EC-HSM P-384 signing key",
+			input: ecHsm,
+			expected: &jsonWebKey{
+				Kty: "EC",
+				Use: "sig",
+				Alg: "ES384",
+				Crv: "P-384",
+			},
+		},
+		{
+			name:  "EC P-521 signing key",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP521, "verify"),
+			expected: &jsonWebKey{
+				Kty: "EC",
+				Use: "sig",
+				Alg: "ES512",
+				Crv: "P-521",
+			},
+		},
+		{
+			// there's no `alg` for key agreement, so this is omitted
+			name:  "EC key without signing operations",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256),
+			expected: &jsonWebKey{
+				Kty: "EC",
+				Crv: "P-256",
+			},
+		},
+		{
+			name:  "EC key with an unsupported curve",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveName("P-192"), "verify"),
+			error: true,
+		},
+		{
+			name: "symmetric key",
+			input: keyvault.JSONWebKey{
+				Kid: utils.String(jsonWebKeyTestKid),
+				Kty: keyvault.JSONWebKeyTypeOct,
+			},
+			error: true,
+		},
+		{
+			name:  "missing kid",
+			input: withoutKid,
+			error: true,
+		},
+		{
+			name:  "RSA key missing the modulus",
+			input: withoutModulus,
+			error: true,
+		},
+		{
+			name:  "EC key missing the y coordinate",
+			input: withoutY,
+			error: true,
+		},
+		{
+			name:  "EC key with an invalid x coordinate",
+			input: withInvalidX,
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual, err := jsonWebKeyFromKeyVaultKey(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %+v", actual)
+		}
+
+		if actual.Kid != jsonWebKeyTestKid {
+			t.Fatalf("expected the kid to be %q but got %q", jsonWebKeyTestKid, actual.Kid)
+		}
+		if actual.Kty != v.expected.Kty || actual.Use != v.expected.Use || actual.Alg != v.expected.Alg || actual.Crv != v.expected.Crv {
+			t.Fatalf("expected kty %q, use %q, alg %q and crv %q but got kty %q, use %q, alg %q and crv %q", v.expected.Kty, v.expected.Use, v.expected.Alg, v.expected.Crv, actual.Kty, actual.Use, actual.Alg, actual.Crv)
+		}
+		if actual.Kty == "RSA" && (actual.N != *v.input.N || actual.E != *v.input.E || actual.X != "" || actual.Y != "") {
+			t.Fatalf("expected only `n` and `e` to be set for an RSA key but got %+v", actual)
+		}
+		if actual.Kty == "EC" && (actual.X != *v.input.X || actual.Y != *v.input.Y || actual.N != "" || actual.E != "") {
+			t.Fatalf("expected only `x` and `y` to be set for an EC key but got %+v", actual)
+		}
+	}
+}
+
+func TestPublicKeyFromJSONWebKey(t *testing.T) {
+	rsaKey := jsonWebKeyTestRSAKey(t)
+	withoutExponent := jsonWebKeyTestRSAKey(t)
+	withoutExponent.E = nil
+	withInvalidModulus := jsonWebKeyTestRSAKey(t)
+	withInvalidModulus.N = utils.String("not base64!")
+	withoutX := jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256)
+	withoutX.X = nil
+
+	testData := []struct {
+		name     string
+		input    keyvault.JSONWebKey
+		expected string
+		error    bool
+	}{
+		{
+			name:     "RSA",
+			input:    rsaKey,
+			expected: "rsa",
+		},
+		{
+			name:     "EC P-256",
+			input:    jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256),
+			expected: "P-256",
+		},
+		{
+			name:     "EC P-384",
+			input:    jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP384),
+			expected: "P-384",
+		},
+		{
+			name:     "EC P-521",
+			input:    jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP521),
+			expected: "P-521",
+		},
+		{
+			// secp256k1 isn't supported by the standard library, so there's no public key
+			name:  "EC P-256K",
+			input: jsonWebKeyTestECKey(t, keyvault.JSONWebKeyCurveNameP256K),
+		},
+		{
+			name: "symmetric key",
+			input: keyvault.JSONWebKey{
+				Kty: keyvault.JSONWebKeyTypeOct,
+			},
+		},
+		{
+			name:  "RSA key missing the exponent",
+			input: withoutExponent,
+			error: true,
+		},
+		{
+			name:  "RSA key with an invalid modulus",
+			input: withInvalidModulus,
+			error: true,
+		},
+		{
+			name:  "EC key missing the x coordinate",
+			input: withoutX,
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual, err := publicKeyFromJSONWebKey(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %+v", actual)
+		}
+
+		switch key := actual.(type) {
+		case *rsa.PublicKey:
+			if v.expected != "rsa" {
+				t.Fatalf("expected %q but got an RSA public key", v.expected)
+			}
+			if base64.RawURLEncoding.EncodeToString(key.N.Bytes()) != *v.input.N {
+				t.Fatalf("expected the modulus to match the input")
+			}
+		case *ecdsa.PublicKey:
+			if key.Curve.Params().Name != v.expected {
+				t.Fatalf("expected the curve %q but got %q", v.expected, key.Curve.Params().Name)
+			}
+			if !key.Curve.IsOnCurve(key.X, key.Y) {
+				t.Fatalf("expected the point to be on the curve")
+			}
+		case nil:
+			if v.expected != "" {
+				t.Fatalf("expected %q but got no public key", v.expected)
+			}
+		default:
+			t.Fatalf("unexpected public key type %T", actual)
+		}
+	}
+}
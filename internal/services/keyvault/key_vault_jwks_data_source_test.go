@@ -0,0 +1,168 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultJWKSDataSource struct{}
+
+func TestAccKeyVaultJWKSDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_jwks", "test")
+	r := KeyVaultJWKSDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("keys.#").HasValue("2"),
+				check.That(data.ResourceName).Key("keys.0.kty").HasValue("RSA"),
+				check.That(data.ResourceName).Key("keys.0.alg").HasValue("RS256"),
+				check.That(data.ResourceName).Key("keys.0.use").HasValue("sig"),
+				check.That(data.ResourceName).Key("keys.1.kty").HasValue("EC"),
+				check.That(data.ResourceName).Key("keys.1.alg").HasValue("ES384"),
+				check.That(data.ResourceName).Key("keys.1.use").HasValue("sig"),
+				check.That(data.ResourceName).Key("json").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultJWKSDataSource_includeAllVersions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_jwks", "test")
+	r := KeyVaultJWKSDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data, "initial"),
+		},
+		{
+			// rotating the RSA key creates a second version
+			Config: r.template(data, "rotated"),
+		},
+		{
+			Config: r.includeAllVersions(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("keys.#").HasValue("2"),
+				check.That(data.ResourceName).Key("json").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultJWKSDataSource_includeAllVersionsWithVersionedId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_jwks", "test")
+	r := KeyVaultJWKSDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config:      r.includeAllVersionsWithVersionedId(data),
+			ExpectError: regexp.MustCompile("must contain versionless Key IDs when `include_all_versions` is enabled"),
+		},
+	})
+}
+
+func (r KeyVaultJWKSDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_jwks" "test" {
+  key_ids = [
+    azurerm_key_vault_key.rsa.versionless_id,
+    azurerm_key_vault_key.ec.id,
+  ]
+}
+`, r.template(data, "initial"))
+}
+
+func (r KeyVaultJWKSDataSource) includeAllVersions(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_jwks" "test" {
+  key_ids              = [azurerm_key_vault_key.rsa.versionless_id]
+  include_all_versions = true
+}
+`, r.template(data, "rotated"))
+}
+
+func (r KeyVaultJWKSDataSource) includeAllVersionsWithVersionedId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_jwks" "test" {
+  key_ids              = [azurerm_key_vault_key.rsa.id]
+  include_all_versions = true
+}
+`, r.template(data, "initial"))
+}
+
+func (KeyVaultJWKSDataSource) template(data acceptance.TestData, rotateOnChange string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                       = "acctestkv-%[3]s"
+  location                   = azurerm_resource_group.test.location
+  resource_group_name        = azurerm_resource_group.test.name
+  tenant_id                  = data.azurerm_client_config.current.tenant_id
+  sku_name                   = "standard"
+  soft_delete_retention_days = 7
+
+  access_policy {
+    tenant_id = data.azurerm_client_config.current.tenant_id
+    object_id = data.azurerm_client_config.current.object_id
+
+    key_permissions = [
+      "Create",
+      "Delete",
+      "Get",
+      "List",
+      "Purge",
+      "Recover",
+      "Rotate",
+      "Update",
+      "GetRotationPolicy",
+    ]
+  }
+}
+
+resource "azurerm_key_vault_key" "rsa" {
+  name             = "rsa-%[3]s"
+  key_vault_id     = azurerm_key_vault.test.id
+  key_type         = "RSA"
+  key_size         = 2048
+  rotate_on_change = "%[4]s"
+
+  key_opts = [
+    "sign",
+    "verify",
+  ]
+}
+
+resource "azurerm_key_vault_key" "ec" {
+  name         = "ec-%[3]s"
+  key_vault_id = azurerm_key_vault.test.id
+  key_type     = "EC"
+  curve        = "P-384"
+
+  key_opts = [
+    "sign",
+    "verify",
+  ]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, rotateOnChange)
+}
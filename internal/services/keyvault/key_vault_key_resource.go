@@ -50,6 +50,25 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			nestedItemPolicyCustomizeDiff(
+				nestedItemPolicyExpirationDate,
+				nestedItemPolicyRequiredTags,
+				nestedItemPolicyKey,
+			),
+			func(ctx context.Context, d *pluginsdk.ResourceDiff, i interface{}) error {
+				// rotating the Key creates a new version, so the version specific attributes will change
+				if d.Id() != "" && d.HasChange("rotate_on_change") && d.Get("rotate_on_change").(string) != "" {
+					for _, key := range []string{"version", "n", "e", "x", "y", "public_key_pem", "public_key_openssh", "resource_id"} {
+						if err := d.SetNewComputed(key); err != nil {
+							return fmt.Errorf("setting `%s` to computed: %+v", key, err)
+						}
+					}
+				}
+				return nil
+			},
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -202,6 +221,12 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 				},
 			},
 
+			"rotate_on_change": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
 			// Computed
 			"version": {
 				Type:     pluginsdk.TypeString,
@@ -336,17 +361,7 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 			}
 			log.Printf("[DEBUG] Recovering Key %q with ID: %q", name, *recoveredKey.Key.Kid)
 			if kid := recoveredKey.Key.Kid; kid != nil {
-				stateConf := &pluginsdk.StateChangeConf{
-					Pending:                   []string{"pending"},
-					Target:                    []string{"available"},
-					Refresh:                   keyVaultChildItemRefreshFunc(*kid),
-					Delay:                     30 * time.Second,
-					PollInterval:              10 * time.Second,
-					ContinuousTargetOccurence: 10,
-					Timeout:                   d.Timeout(pluginsdk.TimeoutCreate),
-				}
-
-				if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				if err := waitForKeyVaultReadiness(ctx, meta.(*clients.Client), *kid, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
 					return fmt.Errorf("waiting for Key Vault Secret %q to become available: %s", name, err)
 				}
 				log.Printf("[DEBUG] Key %q recovered with ID: %q", name, *kid)
@@ -411,6 +426,28 @@ func resourceKeyVaultKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return nil
 	}
 
+	// rotate the Key first, so that the attributes below are applied to the new version
+	if d.HasChange("rotate_on_change") && d.Get("rotate_on_change").(string) != "" {
+		log.Printf("[DEBUG] Rotating Key %q in Key Vault at URI %q", id.Name, id.KeyVaultBaseUrl)
+		resp, err := client.RotateKey(ctx, id.KeyVaultBaseUrl, id.Name)
+		if err != nil {
+			if utils.ResponseWasForbidden(resp.Response) {
+				return fmt.Errorf("current client lacks permissions to rotate Key %q (%q, Vault url: %q), the `rotate` permission is required: %v", id.Name, *keyVaultId, id.KeyVaultBaseUrl, err)
+			}
+			return fmt.Errorf("rotating Key %q (%q, Vault url: %q): %+v", id.Name, *keyVaultId, id.KeyVaultBaseUrl, err)
+		}
+		if resp.Key == nil || resp.Key.Kid == nil {
+			return fmt.Errorf("rotating Key %q (%q, Vault url: %q): `kid` was nil", id.Name, *keyVaultId, id.KeyVaultBaseUrl)
+		}
+
+		rotatedId, err := parse.ParseNestedItemID(*resp.Key.Kid)
+		if err != nil {
+			return err
+		}
+		id = rotatedId
+		d.SetId(id.ID())
+	}
+
 	keyOptions := expandKeyVaultKeyOptions(d)
 	t := d.Get("tags").(map[string]interface{})
 
@@ -536,51 +573,14 @@ func resourceKeyVaultKeyRead(d *pluginsdk.ResourceData, meta interface{}) error
 	d.Set("version", id.Version)
 	d.Set("versionless_id", id.VersionlessID())
 	if key := resp.Key; key != nil {
-		if key.Kty == keyvault.JSONWebKeyTypeRSA || key.Kty == keyvault.JSONWebKeyTypeRSAHSM {
-			nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
-			if err != nil {
-				return fmt.Errorf("failed to decode N: %+v", err)
-			}
-			eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
-			if err != nil {
-				return fmt.Errorf("failed to decode E: %+v", err)
-			}
-			publicKey := &rsa.PublicKey{
-				N: big.NewInt(0).SetBytes(nBytes),
-				E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
-			}
-			err = readPublicKey(d, publicKey)
-			if err != nil {
+		publicKey, err := publicKeyFromJSONWebKey(*key)
+		if err != nil {
+			return err
+		}
+		if publicKey != nil {
+			if err := readPublicKey(d, publicKey); err != nil {
 				return fmt.Errorf("failed to read public key: %+v", err)
 			}
-		} else if key.Kty == keyvault.JSONWebKeyTypeEC || key.Kty == keyvault.JSONWebKeyTypeECHSM {
-			// do ec keys
-			xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
-			if err != nil {
-				return fmt.Errorf("failed to decode X: %+v", err)
-			}
-			yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
-			if err != nil {
-				return fmt.Errorf("failed to decode Y: %+v", err)
-			}
-			publicKey := &ecdsa.PublicKey{
-				X: big.NewInt(0).SetBytes(xBytes),
-				Y: big.NewInt(0).SetBytes(yBytes),
-			}
-			switch key.Crv {
-			case keyvault.JSONWebKeyCurveNameP256:
-				publicKey.Curve = elliptic.P256()
-			case keyvault.JSONWebKeyCurveNameP384:
-				publicKey.Curve = elliptic.P384()
-			case keyvault.JSONWebKeyCurveNameP521:
-				publicKey.Curve = elliptic.P521()
-			}
-			if publicKey.Curve != nil {
-				err = readPublicKey(d, publicKey)
-				if err != nil {
-					return fmt.Errorf("failed to read public key: %+v", err)
-				}
-			}
 		}
 	}
 
@@ -802,6 +802,59 @@ func flattenKeyVaultKeyRotationPolicy(input keyvault.KeyRotationPolicy) []interf
 	return []interface{}{policy}
 }
 
+// publicKeyFromJSONWebKey returns the RSA or ECDSA Public Key for the specified JSON Web Key, or nil when
+// the Key Type (or Curve) isn't supported by the `crypto` package - for example `P-256K`
+func publicKeyFromJSONWebKey(key keyvault.JSONWebKey) (interface{}, error) {
+	switch key.Kty {
+	case keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeRSAHSM:
+		if key.N == nil || key.E == nil {
+			return nil, fmt.Errorf("`n` and `e` must be set for an RSA key")
+		}
+		nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode N: %+v", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(*key.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode E: %+v", err)
+		}
+		return &rsa.PublicKey{
+			N: big.NewInt(0).SetBytes(nBytes),
+			E: int(big.NewInt(0).SetBytes(eBytes).Uint64()),
+		}, nil
+
+	case keyvault.JSONWebKeyTypeEC, keyvault.JSONWebKeyTypeECHSM:
+		if key.X == nil || key.Y == nil {
+			return nil, fmt.Errorf("`x` and `y` must be set for an EC key")
+		}
+		xBytes, err := base64.RawURLEncoding.DecodeString(*key.X)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode X: %+v", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(*key.Y)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Y: %+v", err)
+		}
+		publicKey := &ecdsa.PublicKey{
+			X: big.NewInt(0).SetBytes(xBytes),
+			Y: big.NewInt(0).SetBytes(yBytes),
+		}
+		switch key.Crv {
+		case keyvault.JSONWebKeyCurveNameP256:
+			publicKey.Curve = elliptic.P256()
+		case keyvault.JSONWebKeyCurveNameP384:
+			publicKey.Curve = elliptic.P384()
+		case keyvault.JSONWebKeyCurveNameP521:
+			publicKey.Curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		return publicKey, nil
+	}
+
+	return nil, nil
+}
+
 // Credit to Hashicorp modified from https://github.com/hashicorp/terraform-provider-tls/blob/v3.1.0/internal/provider/util.go#L79-L105
 func readPublicKey(d *pluginsdk.ResourceData, pubKey interface{}) error {
 	pubKeyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
@@ -348,6 +348,49 @@ func TestAccKeyVaultKey_RotationPolicyUnauthorized(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultKey_rotateOnChange(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_key", "test")
+	r := KeyVaultKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicRSA(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("key_size", "key_vault_id"),
+		{
+			Config: r.rotateOnChange(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rotate_on_change").HasValue("first"),
+			),
+		},
+		data.ImportStep("key_size", "key_vault_id", "rotate_on_change"),
+		{
+			Config: r.rotateOnChange(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rotate_on_change").HasValue("second"),
+			),
+		},
+		data.ImportStep("key_size", "key_vault_id", "rotate_on_change"),
+	})
+}
+
+func TestAccKeyVaultKey_featuresPolicyViolation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_key", "test")
+	r := KeyVaultKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.featuresPolicy(data),
+			ExpectError: regexp.MustCompile("`key_size` must be at least 3072"),
+		},
+	})
+}
+
 func (r KeyVaultKeyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	client := clients.KeyVault.ManagementClient
 	keyVaultsClient := clients.KeyVault
@@ -535,6 +578,60 @@ resource "azurerm_key_vault_key" "test" {
 `, r.templateStandard(data), data.RandomString)
 }
 
+func (r KeyVaultKeyResource) rotateOnChange(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_key" "test" {
+  name             = "key-%s"
+  key_vault_id     = azurerm_key_vault.test.id
+  key_type         = "RSA"
+  key_size         = 2048
+  rotate_on_change = "%s"
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+    "sign",
+    "unwrapKey",
+    "verify",
+    "wrapKey",
+  ]
+}
+`, r.templateStandard(data), data.RandomString, trigger)
+}
+
+func (r KeyVaultKeyResource) featuresPolicy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {
+    key_vault {
+      minimum_rsa_key_size = 3072
+      allowed_key_curves   = ["P-384"]
+    }
+  }
+}
+
+%s
+
+resource "azurerm_key_vault_key" "test" {
+  name         = "key-%s"
+  key_vault_id = azurerm_key_vault.test.id
+  key_type     = "RSA"
+  key_size     = 2048
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+  ]
+}
+`, r.templateStandard(data), data.RandomString)
+}
+
 func (r KeyVaultKeyResource) basicRSAHSM(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
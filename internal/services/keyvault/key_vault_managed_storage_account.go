@@ -1,6 +1,7 @@
 package keyvault
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -27,9 +28,15 @@ func resourceKeyVaultManagedStorageAccount() *pluginsdk.Resource {
 		Update: resourceKeyVaultManagedStorageAccountCreateUpdate,
 		Delete: resourceKeyVaultManagedStorageAccountDelete,
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
 			_, err := keyVaultParse.ParseOptionallyVersionedNestedItemID(id)
 			return err
+		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+			// the Domain Suffix of the Base URL is validated against the active environment
+			if _, err := meta.(*clients.Client).KeyVault.ParseOptionallyVersionedNestedItemID(d.Id()); err != nil {
+				return []*pluginsdk.ResourceData{d}, fmt.Errorf("parsing ID %q for Managed Storage Account import: %+v", d.Id(), err)
+			}
+			return []*pluginsdk.ResourceData{d}, nil
 		}),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
@@ -39,6 +46,18 @@ func resourceKeyVaultManagedStorageAccount() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(func(ctx context.Context, d *pluginsdk.ResourceDiff, i interface{}) error {
+			// regenerating the key updates the Managed Storage Account, so the computed attributes will change
+			if d.Id() != "" && d.HasChange("regenerate_key_trigger") && d.Get("regenerate_key_trigger").(string) != "" {
+				for _, key := range []string{"active_key_name", "last_regeneration_time"} {
+					if err := d.SetNewComputed(key); err != nil {
+						return fmt.Errorf("setting `%s` to computed: %+v", key, err)
+					}
+				}
+			}
+			return nil
+		}),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -83,7 +102,23 @@ func resourceKeyVaultManagedStorageAccount() *pluginsdk.Resource {
 				RequiredWith: []string{"regenerate_key_automatically"},
 			},
 
+			"regenerate_key_trigger": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
 			"tags": tags.ForceNewSchema(),
+
+			"active_key_name": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"last_regeneration_time": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -139,17 +174,7 @@ func resourceKeyVaultManagedStorageAccountCreateUpdate(d *pluginsdk.ResourceData
 			log.Printf("[DEBUG] Recovering Managed Storage Account %q (Key Vault %q)", name, *keyVaultBaseUrl)
 			// We need to wait for consistency, recovered Key Vault Child items are not as readily available as newly created
 			if secret := recoveredStorageAccount.ID; secret != nil {
-				stateConf := &pluginsdk.StateChangeConf{
-					Pending:                   []string{"pending"},
-					Target:                    []string{"available"},
-					Refresh:                   keyVaultChildItemRefreshFunc(*secret),
-					Delay:                     30 * time.Second,
-					PollInterval:              10 * time.Second,
-					ContinuousTargetOccurence: 10,
-					Timeout:                   d.Timeout(pluginsdk.TimeoutCreate),
-				}
-
-				if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				if err := waitForKeyVaultReadiness(ctx, meta.(*clients.Client), *secret, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
 					return fmt.Errorf("waiting for Managed Storage Account %q (Key Vault %q) to become available after recovery: %s", name, *keyVaultId, err)
 				}
 				log.Printf("[DEBUG] Managed Storage Account %q recovered with ID: %q", name, *recoveredStorageAccount.ID)
@@ -163,6 +188,23 @@ func resourceKeyVaultManagedStorageAccountCreateUpdate(d *pluginsdk.ResourceData
 		}
 	}
 
+	if !d.IsNewResource() && d.HasChange("regenerate_key_trigger") && d.Get("regenerate_key_trigger").(string) != "" {
+		keyName := d.Get("storage_account_key").(string)
+		log.Printf("[DEBUG] Regenerating key %q for Managed Storage Account %q (Key Vault %q)", keyName, name, *keyVaultId)
+		regenerateParameters := keyvault.StorageAccountRegenerteKeyParameters{
+			KeyName: utils.String(keyName),
+		}
+		if resp, err := client.RegenerateStorageAccountKey(ctx, *keyVaultBaseUrl, name, regenerateParameters); err != nil {
+			if utils.ResponseWasForbidden(resp.Response) {
+				return fmt.Errorf("current client lacks permissions to regenerate key %q for Managed Storage Account %q (Key Vault %q), the `RegenerateKey` storage permission is required: %+v", keyName, name, *keyVaultId, err)
+			}
+			return fmt.Errorf("regenerating key %q for Managed Storage Account %q (Key Vault %q): %+v", keyName, name, *keyVaultId, err)
+		}
+
+		// the API doesn't expose when the key was last regenerated (`updated` changes on any update), so this is tracked in the state
+		d.Set("last_regeneration_time", time.Now().UTC().Format(time.RFC3339))
+	}
+
 	read, err := client.GetStorageAccount(ctx, *keyVaultBaseUrl, name)
 	if err != nil {
 		return fmt.Errorf("cannot read Managed Storage Account %q (Key Vault %q): %+v", name, *keyVaultId, err)
@@ -229,6 +271,7 @@ func resourceKeyVaultManagedStorageAccountRead(d *pluginsdk.ResourceData, meta i
 	d.Set("storage_account_key", resp.ActiveKeyName)
 	d.Set("regenerate_key_automatically", resp.AutoRegenerateKey)
 	d.Set("regeneration_period", resp.RegenerationPeriod)
+	d.Set("active_key_name", resp.ActiveKeyName)
 
 	return tags.FlattenAndSet(d, resp.Tags)
 }
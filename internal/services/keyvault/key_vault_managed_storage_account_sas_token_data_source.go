@@ -0,0 +1,188 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	storageParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = KeyVaultManagedStorageAccountSasTokenDataSource{}
+
+type KeyVaultManagedStorageAccountSasTokenDataSource struct{}
+
+type KeyVaultManagedStorageAccountSasTokenDataSourceModel struct {
+	SasDefinitionId    string `tfschema:"sas_definition_id"`
+	Service            string `tfschema:"service"`
+	SasToken           string `tfschema:"sas_token"`
+	Expiry             string `tfschema:"expiry"`
+	ServiceUrl         string `tfschema:"service_url"`
+	SecretId           string `tfschema:"secret_id"`
+	StorageAccountName string `tfschema:"storage_account_name"`
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"sas_definition_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.SasDefinitionId,
+		},
+
+		"service": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  "blob",
+			ValidateFunc: validation.StringInSlice([]string{
+				"blob",
+				"dfs",
+				"file",
+				"queue",
+				"table",
+			}, false),
+		},
+	}
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"sas_token": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"expiry": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"service_url": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"secret_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"storage_account_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) ModelObject() interface{} {
+	return &KeyVaultManagedStorageAccountSasTokenDataSourceModel{}
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) ResourceType() string {
+	return "azurerm_key_vault_managed_storage_account_sas_token"
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.ManagementClient
+
+			var model KeyVaultManagedStorageAccountSasTokenDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := parse.SasDefinitionID(model.SasDefinitionId)
+			if err != nil {
+				return err
+			}
+
+			sasDefinition, err := client.GetSasDefinition(ctx, id.KeyVaultBaseUrl, id.StorageAccountName, id.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving Managed Storage Account Sas Definition %q (Storage Account %q, Key Vault at URI %q): %+v", id.Name, id.StorageAccountName, id.KeyVaultBaseUrl, err)
+			}
+			if sasDefinition.SecretID == nil {
+				return fmt.Errorf("retrieving Managed Storage Account Sas Definition %q (Storage Account %q, Key Vault at URI %q): `sid` was nil", id.Name, id.StorageAccountName, id.KeyVaultBaseUrl)
+			}
+
+			secretId, err := parse.ParseOptionallyVersionedNestedItemID(*sasDefinition.SecretID)
+			if err != nil {
+				return err
+			}
+
+			// the SAS Token is generated by Key Vault when the backing Secret is retrieved
+			secret, err := client.GetSecret(ctx, secretId.KeyVaultBaseUrl, secretId.Name, secretId.Version)
+			if err != nil {
+				return fmt.Errorf("retrieving the SAS Token from Secret %q (Key Vault at URI %q): %+v", secretId.Name, secretId.KeyVaultBaseUrl, err)
+			}
+			if secret.Value == nil {
+				return fmt.Errorf("retrieving the SAS Token from Secret %q (Key Vault at URI %q): `value` was nil", secretId.Name, secretId.KeyVaultBaseUrl)
+			}
+			sasToken := strings.TrimPrefix(*secret.Value, "?")
+
+			expiry, err := parseSasTokenExpiry(sasToken)
+			if err != nil {
+				return err
+			}
+
+			storageAccount, err := client.GetStorageAccount(ctx, id.KeyVaultBaseUrl, id.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Managed Storage Account %q (Key Vault at URI %q): %+v", id.StorageAccountName, id.KeyVaultBaseUrl, err)
+			}
+			if storageAccount.ResourceID == nil {
+				return fmt.Errorf("retrieving Managed Storage Account %q (Key Vault at URI %q): `resourceId` was nil", id.StorageAccountName, id.KeyVaultBaseUrl)
+			}
+			storageAccountId, err := storageParse.StorageAccountID(*storageAccount.ResourceID)
+			if err != nil {
+				return err
+			}
+
+			environment := metadata.Client.Account.Environment
+			storageDomainSuffix, ok := environment.Storage.DomainSuffix()
+			if !ok {
+				return fmt.Errorf("could not determine Storage domain suffix for environment %q", environment.Name)
+			}
+
+			model.SasToken = sasToken
+			model.Expiry = expiry
+			model.SecretId = *sasDefinition.SecretID
+			model.StorageAccountName = storageAccountId.Name
+			model.ServiceUrl = fmt.Sprintf("https://%s.%s.%s/?%s", storageAccountId.Name, model.Service, *storageDomainSuffix, sasToken)
+
+			metadata.ResourceData.SetId(model.SasDefinitionId)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// parseSasTokenExpiry returns the expiry of the SAS Token in RFC3339 format, which is specified in the `se`
+// (signed expiry) field as either a date or a date and time
+func parseSasTokenExpiry(sasToken string) (string, error) {
+	values, err := url.ParseQuery(sasToken)
+	if err != nil {
+		return "", fmt.Errorf("parsing SAS Token: %+v", err)
+	}
+
+	signedExpiry := values.Get("se")
+	if signedExpiry == "" {
+		return "", fmt.Errorf("parsing SAS Token: the signed expiry (`se`) was not found")
+	}
+
+	for _, format := range []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02"} {
+		if expiry, err := time.Parse(format, signedExpiry); err == nil {
+			return expiry.UTC().Format(time.RFC3339), nil
+		}
+	}
+
+	return "", fmt.Errorf("parsing SAS Token: the signed expiry (`se`) %q is not a valid ISO 8601 date", signedExpiry)
+}
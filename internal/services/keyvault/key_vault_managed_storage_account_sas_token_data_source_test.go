@@ -0,0 +1,40 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultManagedStorageAccountSasTokenDataSource struct{}
+
+func TestAccKeyVaultManagedStorageAccountSasTokenDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vault_managed_storage_account_sas_token", "test")
+	r := KeyVaultManagedStorageAccountSasTokenDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sas_token").Exists(),
+				check.That(data.ResourceName).Key("expiry").Exists(),
+				check.That(data.ResourceName).Key("service_url").Exists(),
+				check.That(data.ResourceName).Key("secret_id").Exists(),
+				check.That(data.ResourceName).Key("storage_account_name").Exists(),
+			),
+		},
+	})
+}
+
+func (KeyVaultManagedStorageAccountSasTokenDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vault_managed_storage_account_sas_token" "test" {
+  sas_definition_id = azurerm_key_vault_managed_storage_account_sas_token_definition.test.id
+  service           = "queue"
+}
+`, KeyVaultManagedStorageAccountSasTokenDefinitionResource{}.basic(data))
+}
@@ -0,0 +1,74 @@
+package keyvault
+
+import (
+	"testing"
+)
+
+func TestParseSasTokenExpiry(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+		error    bool
+	}{
+		{
+			// RFC3339
+			input:    "sv=2021-06-08&ss=b&srt=sco&sp=rl&se=2023-04-01T12:30:00Z&sig=abc123",
+			expected: "2023-04-01T12:30:00Z",
+		},
+		{
+			// without seconds, with an offset
+			input:    "sv=2021-06-08&se=2023-04-01T12:30%2B02:00&sig=abc123",
+			expected: "2023-04-01T10:30:00Z",
+		},
+		{
+			// date only
+			input:    "se=2023-04-01&sig=abc123",
+			expected: "2023-04-01T00:00:00Z",
+		},
+		{
+			// URL-encoded
+			input:    "sv=2021-06-08&se=2023-04-01T12%3A30%3A00Z&sig=abc%2B123%3D",
+			expected: "2023-04-01T12:30:00Z",
+		},
+		{
+			// missing `se`
+			input: "sv=2021-06-08&ss=b&srt=sco&sp=rl&sig=abc123",
+			error: true,
+		},
+		{
+			// empty `se`
+			input: "sv=2021-06-08&se=&sig=abc123",
+			error: true,
+		},
+		{
+			// invalid time
+			input: "sv=2021-06-08&se=next-tuesday&sig=abc123",
+			error: true,
+		},
+		{
+			// invalid query string
+			input: "se=%zz",
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		actual, err := parseSasTokenExpiry(v.input)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %q", actual)
+		}
+
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
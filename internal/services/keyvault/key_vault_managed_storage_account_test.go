@@ -93,6 +93,41 @@ func TestAccKeyVaultManagedStorageAccount_update(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultManagedStorageAccount_regenerateKeyTrigger(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_storage_account", "test")
+	r := KeyVaultManagedStorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, true, "P1D"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("active_key_name").HasValue("key1"),
+				check.That(data.ResourceName).Key("last_regeneration_time").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.regenerateKeyTrigger(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("regenerate_key_trigger").HasValue("first"),
+				check.That(data.ResourceName).Key("last_regeneration_time").Exists(),
+			),
+		},
+		data.ImportStep("regenerate_key_trigger", "last_regeneration_time"),
+		{
+			Config: r.regenerateKeyTrigger(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("regenerate_key_trigger").HasValue("second"),
+				check.That(data.ResourceName).Key("last_regeneration_time").Exists(),
+			),
+		},
+		data.ImportStep("regenerate_key_trigger", "last_regeneration_time"),
+	})
+}
+
 func TestAccKeyVaultManagedStorageAccount_recovery(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_storage_account", "test")
 	r := KeyVaultManagedStorageAccountResource{}
@@ -193,6 +228,44 @@ resource "azurerm_key_vault_managed_storage_account" "test" {
 `, r.template(data), autoGenerateKey, regenPeriod)
 }
 
+func (r KeyVaultManagedStorageAccountResource) regenerateKeyTrigger(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+provider "azuread" {}
+
+data "azuread_service_principal" "test" {
+  display_name = "Azure Key Vault"
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_storage_account.test.id
+  role_definition_name = "Storage Account Key Operator Service Role"
+  principal_id         = data.azuread_service_principal.test.id
+}
+
+resource "azurerm_key_vault_managed_storage_account" "test" {
+  name                         = "acctestKVstorage"
+  key_vault_id                 = azurerm_key_vault.test.id
+  storage_account_id           = azurerm_storage_account.test.id
+  storage_account_key          = "key1"
+  regenerate_key_automatically = true
+  regeneration_period          = "P1D"
+  regenerate_key_trigger       = "%s"
+
+  tags = {
+    "hello" = "world"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), trigger)
+}
+
 func (r KeyVaultManagedStorageAccountResource) softDeleteRecovery(data acceptance.TestData, purge bool, name string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,67 @@
+package keyvault
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// KeyVaultNestedItemModel is a Nested Item (e.g. a Certificate or Secret) returned when listing the
+// Nested Items within a Key Vault - which is shared by the plural Nested Item Data Sources
+type KeyVaultNestedItemModel struct {
+	Id      string `tfschema:"id"`
+	Name    string `tfschema:"name"`
+	Enabled bool   `tfschema:"enabled"`
+}
+
+func keyVaultNestedItemNamesSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+}
+
+func keyVaultNestedItemsSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"enabled": {
+					Type:     pluginsdk.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// listKeyVaultNestedItems pages through all of the Nested Items returned from the iterator, returning the
+// names of the Nested Items alongside the Nested Items themselves
+func listKeyVaultNestedItems[T any](ctx context.Context, iterator sdk.AutoRestIterator[T], mapFunc func(item T) (*KeyVaultNestedItemModel, error)) ([]string, []KeyVaultNestedItemModel, error) {
+	items, err := sdk.ListFromAutoRestIterator(ctx, iterator, 0, mapFunc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+
+	return names, items, nil
+}
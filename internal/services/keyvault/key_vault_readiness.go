@@ -0,0 +1,137 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+// keyVaultReadinessProbeTimeout is the maximum duration of a single attempt, so that an unreachable
+// data plane (e.g. one behind a Private Endpoint) is retried rather than hanging until the overall timeout
+const keyVaultReadinessProbeTimeout = 30 * time.Second
+
+type keyVaultReadinessProbe func(ctx context.Context, uri string) error
+
+// waitForKeyVaultReadiness waits until the Key Vault data plane at the specified URI is available, using
+// the readiness probe configured within the `features` block - unless this has been skipped entirely
+func waitForKeyVaultReadiness(ctx context.Context, client *clients.Client, uri string, timeout time.Duration) error {
+	probeName := client.Features.KeyVault.VaultReadinessProbe
+	var probe keyVaultReadinessProbe
+	switch probeName {
+	case features.VaultReadinessProbeSkip:
+		log.Printf("[DEBUG] Skipping the readiness probe for %q as opted-out", uri)
+		return nil
+	case features.VaultReadinessProbeDnsResolution:
+		probe = keyVaultDnsResolutionProbe
+	case features.VaultReadinessProbeDataPlane:
+		probe = keyVaultDataPlaneProbe(client.KeyVault.ManagementClient)
+	default:
+		return fmt.Errorf("unsupported Key Vault readiness probe %q", probeName)
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending:                   []string{"pending"},
+		Target:                    []string{"available"},
+		Refresh:                   keyVaultReadinessRefreshFunc(ctx, uri, probeName, probe),
+		Delay:                     30 * time.Second,
+		PollInterval:              10 * time.Second,
+		ContinuousTargetOccurence: 10,
+		Timeout:                   timeout,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func keyVaultReadinessRefreshFunc(ctx context.Context, uri string, probeName string, probe keyVaultReadinessProbe) pluginsdk.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Checking to see if %q is available using the %q readiness probe..", uri, probeName)
+
+		probeCtx, cancel := context.WithTimeout(ctx, keyVaultReadinessProbeTimeout)
+		defer cancel()
+
+		if err := probe(probeCtx, uri); err != nil {
+			log.Printf("[DEBUG] The %q readiness probe for %q failed: %+v", probeName, uri, err)
+			return uri, "pending", nil
+		}
+
+		log.Printf("[DEBUG] %q is available", uri)
+		return uri, "available", nil
+	}
+}
+
+// keyVaultDnsResolutionProbe checks that the hostname for the URI can be resolved, which works when the
+// data plane isn't reachable from where Terraform is running, for example when public access is disabled
+func keyVaultDnsResolutionProbe(ctx context.Context, uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("parsing %q: %+v", uri, err)
+	}
+
+	if _, err := net.DefaultResolver.LookupHost(ctx, parsed.Hostname()); err != nil {
+		return fmt.Errorf("resolving %q: %+v", parsed.Hostname(), err)
+	}
+
+	return nil
+}
+
+// keyVaultDataPlaneProbe makes an authenticated request to the URI using the data plane client, so
+// that the Proxy and Authorizer configured for the Provider are used
+func keyVaultDataPlaneProbe(client *keyvault.BaseClient) keyVaultReadinessProbe {
+	return func(ctx context.Context, uri string) error {
+		apiVersion, err := keyVaultDataPlaneAPIVersion(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+			autorest.AsGet(),
+			autorest.WithBaseURL(uri),
+			autorest.WithQueryParameters(map[string]interface{}{
+				"api-version": apiVersion,
+			}))
+		if err != nil {
+			return fmt.Errorf("preparing request: %+v", err)
+		}
+
+		resp, err := client.Send(req)
+		if err != nil {
+			return fmt.Errorf("sending request: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		// the caller may not have permission to read the item, but a response means the data plane is available -
+		// whereas a 404 means that it's not been replicated yet
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+// keyVaultDataPlaneAPIVersion returns the API Version used by the data plane client - which isn't exported
+// by the SDK, so is taken from a request prepared by the client
+func keyVaultDataPlaneAPIVersion(ctx context.Context, client *keyvault.BaseClient) (string, error) {
+	req, err := client.GetSecretsPreparer(ctx, "https://example.vault.azure.net", nil)
+	if err != nil {
+		return "", fmt.Errorf("determining the API Version for the data plane: %+v", err)
+	}
+
+	apiVersion := req.URL.Query().Get("api-version")
+	if apiVersion == "" {
+		return "", fmt.Errorf("determining the API Version for the data plane: `api-version` was empty")
+	}
+
+	return apiVersion, nil
+}
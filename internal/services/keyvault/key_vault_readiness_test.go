@@ -0,0 +1,109 @@
+package keyvault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+func TestKeyVaultDataPlaneProbe(t *testing.T) {
+	testData := []struct {
+		statusCode int
+		expected   string
+	}{
+		{
+			statusCode: http.StatusOK,
+			expected:   "available",
+		},
+		{
+			// the caller may not be authenticated, but the data plane is available
+			statusCode: http.StatusUnauthorized,
+			expected:   "available",
+		},
+		{
+			// the caller may not have permission, but the data plane is available
+			statusCode: http.StatusForbidden,
+			expected:   "available",
+		},
+		{
+			// the data plane hasn't been replicated yet
+			statusCode: http.StatusNotFound,
+			expected:   "pending",
+		},
+		{
+			statusCode: http.StatusServiceUnavailable,
+			expected:   "pending",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %d", v.statusCode)
+
+		var apiVersion string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiVersion = r.URL.Query().Get("api-version")
+			w.WriteHeader(v.statusCode)
+		}))
+
+		client := keyvault.New()
+		probe := keyVaultDataPlaneProbe(&client)
+		_, state, err := keyVaultReadinessRefreshFunc(context.TODO(), server.URL, features.VaultReadinessProbeDataPlane, probe)()
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if state != v.expected {
+			t.Fatalf("expected the state to be %q but got %q", v.expected, state)
+		}
+		if apiVersion != "7.4" {
+			t.Fatalf("expected the request to use the API Version of the client (%q) but got %q", "7.4", apiVersion)
+		}
+	}
+}
+
+func TestKeyVaultDataPlaneProbeConnectionError(t *testing.T) {
+	// closing the server means that the connection is refused, which should be retried
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	uri := server.URL
+	server.Close()
+
+	client := keyvault.New()
+	probe := keyVaultDataPlaneProbe(&client)
+	_, state, err := keyVaultReadinessRefreshFunc(context.TODO(), uri, features.VaultReadinessProbeDataPlane, probe)()
+	if err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+	if state != "pending" {
+		t.Fatalf("expected the state to be %q but got %q", "pending", state)
+	}
+}
+
+func TestWaitForKeyVaultReadinessSkip(t *testing.T) {
+	client := &clients.Client{
+		Features: features.Default(),
+	}
+	client.Features.KeyVault.VaultReadinessProbe = features.VaultReadinessProbeSkip
+
+	// the data plane client isn't configured, so this would fail if the probe were run
+	if err := waitForKeyVaultReadiness(context.TODO(), client, "https://unreachable.vault.azure.net/", time.Minute); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+}
+
+func TestWaitForKeyVaultReadinessUnsupportedProbe(t *testing.T) {
+	client := &clients.Client{
+		Features: features.Default(),
+	}
+	client.Features.KeyVault.VaultReadinessProbe = "Unknown"
+
+	if err := waitForKeyVaultReadiness(context.TODO(), client, "https://unreachable.vault.azure.net/", time.Minute); err == nil {
+		t.Fatalf("expected an error for an unsupported readiness probe")
+	}
+}
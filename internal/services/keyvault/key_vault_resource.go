@@ -3,8 +3,6 @@ package keyvault
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strings"
 	"time"
 
@@ -14,10 +12,9 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
-	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	commonValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
@@ -27,7 +24,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/set"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	KeyVaultMgmt "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
@@ -39,835 +35,882 @@ var armKeyVaultSkuFamily = "A"
 
 var keyVaultResourceName = "customkv_key_vault"
 
-func resourceKeyVault() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Create: resourceKeyVaultCreate,
-		Read:   resourceKeyVaultRead,
-		Update: resourceKeyVaultUpdate,
-		Delete: resourceKeyVaultDelete,
+type KeyVaultResource struct{}
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
-			_, err := parse.VaultID(id)
-			return err
-		}),
+var (
+	_ sdk.ResourceWithUpdate         = KeyVaultResource{}
+	_ sdk.ResourceWithStateMigration = KeyVaultResource{}
+	_ sdk.ResourceWithCustomizeDiff  = KeyVaultResource{}
+)
 
-		SchemaVersion: 2,
-		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
-			0: migration.KeyVaultV0ToV1{},
-			1: migration.KeyVaultV1ToV2{},
-		}),
+type KeyVaultResourceModel struct {
+	Name                         string                      `tfschema:"name"`
+	Location                     string                      `tfschema:"location"`
+	ResourceGroupName            string                      `tfschema:"resource_group_name"`
+	SkuName                      string                      `tfschema:"sku_name"`
+	TenantId                     string                      `tfschema:"tenant_id"`
+	AccessPolicy                 []KeyVaultAccessPolicyModel `tfschema:"access_policy"`
+	EnabledForDeployment         bool                        `tfschema:"enabled_for_deployment"`
+	EnabledForDiskEncryption     bool                        `tfschema:"enabled_for_disk_encryption"`
+	EnabledForTemplateDeployment bool                        `tfschema:"enabled_for_template_deployment"`
+	EnableRbacAuthorization      bool                        `tfschema:"enable_rbac_authorization"`
+	NetworkAcls                  []KeyVaultNetworkAclsModel  `tfschema:"network_acls"`
+	PublicNetworkAccessEnabled   bool                        `tfschema:"public_network_access_enabled"`
+	PurgeProtectionEnabled       bool                        `tfschema:"purge_protection_enabled"`
+	SoftDeleteRetentionDays      int                         `tfschema:"soft_delete_retention_days"`
+	Contact                      []Contact                   `tfschema:"contact"`
+	Tags                         map[string]string           `tfschema:"tags"`
+	VaultUri                     string                      `tfschema:"vault_uri"`
+}
 
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
-			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
-			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
-		},
+type KeyVaultAccessPolicyModel struct {
+	TenantId                 string   `tfschema:"tenant_id"`
+	ObjectId                 string   `tfschema:"object_id"`
+	UserPrincipalName        string   `tfschema:"user_principal_name"`
+	GroupDisplayName         string   `tfschema:"group_display_name"`
+	ServicePrincipalClientId string   `tfschema:"service_principal_client_id"`
+	ApplicationId            string   `tfschema:"application_id"`
+	CertificatePermissions   []string `tfschema:"certificate_permissions"`
+	KeyPermissions           []string `tfschema:"key_permissions"`
+	SecretPermissions        []string `tfschema:"secret_permissions"`
+	StoragePermissions       []string `tfschema:"storage_permissions"`
+}
 
-		Schema: map[string]*pluginsdk.Schema{
-			"name": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validate.VaultName,
-			},
+type KeyVaultNetworkAclsModel struct {
+	DefaultAction           string   `tfschema:"default_action"`
+	Bypass                  string   `tfschema:"bypass"`
+	IPRules                 []string `tfschema:"ip_rules"`
+	VirtualNetworkSubnetIds []string `tfschema:"virtual_network_subnet_ids"`
+}
 
-			"location": commonschema.Location(),
+func (r KeyVaultResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.VaultName,
+		},
 
-			"resource_group_name": commonschema.ResourceGroupName(),
+		"location": commonschema.Location(),
 
-			"sku_name": {
-				Type:     pluginsdk.TypeString,
-				Required: true,
-				ValidateFunc: validation.StringInSlice([]string{
-					string(keyvault.SkuNameStandard),
-					string(keyvault.SkuNamePremium),
-				}, false),
-			},
+		"resource_group_name": commonschema.ResourceGroupName(),
 
-			"tenant_id": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: validation.IsUUID,
-			},
+		"sku_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(keyvault.SkuNameStandard),
+				string(keyvault.SkuNamePremium),
+			}, false),
+		},
 
-			"access_policy": {
-				Type:       pluginsdk.TypeList,
-				ConfigMode: pluginsdk.SchemaConfigModeAttr,
-				Optional:   true,
-				Computed:   true,
-				MaxItems:   1024,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"tenant_id": {
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.IsUUID,
-						},
-						"object_id": {
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.IsUUID,
-						},
-						"application_id": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validate.IsUUIDOrEmpty,
-						},
-						"certificate_permissions": schemaCertificatePermissions(),
-						"key_permissions":         schemaKeyPermissions(),
-						"secret_permissions":      schemaSecretPermissions(),
-						"storage_permissions":     schemaStoragePermissions(),
+		"tenant_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"access_policy": {
+			Type:       pluginsdk.TypeList,
+			ConfigMode: pluginsdk.SchemaConfigModeAttr,
+			Optional:   true,
+			Computed:   true,
+			MaxItems:   1024,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"tenant_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsUUID,
+					},
+					"object_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IsUUID,
+					},
+					"user_principal_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"group_display_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"service_principal_client_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsUUID,
 					},
+					"application_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validate.IsUUIDOrEmpty,
+					},
+					"certificate_permissions": schemaCertificatePermissions(),
+					"key_permissions":         schemaKeyPermissions(),
+					"secret_permissions":      schemaSecretPermissions(),
+					"storage_permissions":     schemaStoragePermissions(),
 				},
 			},
+		},
 
-			"enabled_for_deployment": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-			},
+		"enabled_for_deployment": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
 
-			"enabled_for_disk_encryption": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-			},
+		"enabled_for_disk_encryption": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
 
-			"enabled_for_template_deployment": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-			},
+		"enabled_for_template_deployment": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
 
-			// TODO 4.0: change this from enable_* to *_enabled
-			"enable_rbac_authorization": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-			},
+		// TODO 4.0: change this from enable_* to *_enabled
+		"enable_rbac_authorization": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
 
-			"network_acls": {
-				Type:     pluginsdk.TypeList,
-				Optional: true,
-				Computed: true,
-				MaxItems: 1,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"default_action": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(keyvault.NetworkRuleActionAllow),
-								string(keyvault.NetworkRuleActionDeny),
-							}, false),
-						},
-						"bypass": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(keyvault.NetworkRuleBypassOptionsNone),
-								string(keyvault.NetworkRuleBypassOptionsAzureServices),
-							}, false),
-						},
-						"ip_rules": {
-							Type:     pluginsdk.TypeSet,
-							Optional: true,
-							Elem: &pluginsdk.Schema{
-								Type: pluginsdk.TypeString,
-								ValidateFunc: validation.Any(
-									commonValidate.IPv4Address,
-									commonValidate.CIDR,
-								),
-							},
-							Set: set.HashIPv4AddressOrCIDR,
-						},
-						"virtual_network_subnet_ids": {
-							Type:     pluginsdk.TypeSet,
-							Optional: true,
-							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
-							Set:      set.HashStringIgnoreCase,
+		"network_acls": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"default_action": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(keyvault.NetworkRuleActionAllow),
+							string(keyvault.NetworkRuleActionDeny),
+						}, false),
+					},
+					"bypass": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(keyvault.NetworkRuleBypassOptionsNone),
+							string(keyvault.NetworkRuleBypassOptionsAzureServices),
+						}, false),
+					},
+					"ip_rules": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+							ValidateFunc: validation.Any(
+								commonValidate.IPv4Address,
+								commonValidate.CIDR,
+							),
 						},
+						Set: set.HashIPv4AddressOrCIDR,
+					},
+					"virtual_network_subnet_ids": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						Set:      set.HashStringIgnoreCase,
 					},
 				},
 			},
+		},
 
-			"public_network_access_enabled": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-				Default:  true,
-			},
+		"public_network_access_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
 
-			"purge_protection_enabled": {
-				Type:     pluginsdk.TypeBool,
-				Optional: true,
-			},
+		"purge_protection_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+		},
 
-			"soft_delete_retention_days": {
-				Type:         pluginsdk.TypeInt,
-				Optional:     true,
-				Default:      90,
-				ValidateFunc: validation.IntBetween(7, 90),
-			},
+		"soft_delete_retention_days": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      90,
+			ValidateFunc: validation.IntBetween(7, 90),
+		},
 
-			"contact": {
-				Type:     pluginsdk.TypeSet,
-				Optional: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*pluginsdk.Schema{
-						"email": {
-							Type:     pluginsdk.TypeString,
-							Required: true,
-						},
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
-						"phone": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-						},
+		"contact": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"email": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+					},
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+					},
+					"phone": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
 					},
 				},
 			},
+		},
 
-			"tags": tags.Schema(),
+		"tags": tags.Schema(),
+	}
+}
 
-			// Computed
-			"vault_uri": {
-				Type:     pluginsdk.TypeString,
-				Computed: true,
-			},
+func (r KeyVaultResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"vault_uri": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
 		},
 	}
 }
 
-func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
-	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
-	client := meta.(*clients.Client).KeyVault.VaultsClient
-	dataPlaneClient := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (r KeyVaultResource) ResourceType() string {
+	return keyVaultResourceName
+}
 
-	id := parse.NewVaultID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
-	location := azure.NormalizeLocation(d.Get("location").(string))
+func (r KeyVaultResource) ModelObject() interface{} {
+	return &KeyVaultResourceModel{}
+}
 
-	// Locking this resource so we don't make modifications to it at the same time if there is a
-	// key vault access policy trying to update it as well
-	locks.ByName(id.Name, keyVaultResourceName)
-	defer locks.UnlockByName(id.Name, keyVaultResourceName)
+func (r KeyVaultResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.VaultID
+}
 
-	// check for the presence of an existing, live one which should be imported into the state
-	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		if !utils.ResponseWasNotFound(existing.Response) {
-			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
-		}
+func (r KeyVaultResource) StateUpgraders() sdk.StateUpgradeData {
+	return sdk.StateUpgradeData{
+		SchemaVersion: 2,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			0: migration.KeyVaultV0ToV1{},
+			1: migration.KeyVaultV1ToV2{},
+		},
 	}
+}
 
-	if !utils.ResponseWasNotFound(existing.Response) {
-		return tf.ImportAsExistsError("customkv_key_vault", id.ID())
+func (r KeyVaultResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return keyVaultAccessPoliciesPrincipalCustomizeDiff(ctx, metadata.ResourceDiff, metadata.Client)
+		},
+		Timeout: 5 * time.Minute,
 	}
+}
 
-	// before creating check to see if the key vault exists in the soft delete state
-	softDeletedKeyVault, err := client.GetDeleted(ctx, id.Name, location)
-	if err != nil {
-		// If Terraform lacks permission to read at the Subscription we'll get 409, not 404
-		if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
-			return fmt.Errorf("checking for the presence of an existing Soft-Deleted Key Vault %q (Location %q): %+v", id.Name, location, err)
-		}
-	}
+func (r KeyVaultResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			subscriptionId := metadata.Client.Account.SubscriptionId
+			client := metadata.Client.KeyVault.VaultsClient
+			dataPlaneClient := metadata.Client.KeyVault.ManagementClient
 
-	// if so, does the user want us to recover it?
+			var model KeyVaultResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	recoverSoftDeletedKeyVault := false
-	if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
-		if !meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeyVaults {
-			// this exists but the users opted out so they must import this it out-of-band
-			return fmt.Errorf(optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(id.Name, location))
-		}
+			id := parse.NewVaultID(subscriptionId, model.ResourceGroupName, model.Name)
+			location := azure.NormalizeLocation(model.Location)
 
-		recoverSoftDeletedKeyVault = true
-	}
+			// Locking this resource so we don't make modifications to it at the same time if there is a
+			// key vault access policy trying to update it as well
+			locks.ByName(id.Name, keyVaultResourceName)
+			defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
-	tenantUUID := uuid.FromStringOrNil(d.Get("tenant_id").(string))
-	enabledForDeployment := d.Get("enabled_for_deployment").(bool)
-	enabledForDiskEncryption := d.Get("enabled_for_disk_encryption").(bool)
-	enabledForTemplateDeployment := d.Get("enabled_for_template_deployment").(bool)
-	enableRbacAuthorization := d.Get("enable_rbac_authorization").(bool)
-	t := d.Get("tags").(map[string]interface{})
+			// check for the presence of an existing, live one which should be imported into the state
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if !utils.ResponseWasNotFound(existing.Response) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
 
-	policies := d.Get("access_policy").([]interface{})
-	accessPolicies := expandAccessPolicies(policies)
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
 
-	networkAclsRaw := d.Get("network_acls").([]interface{})
-	networkAcls, subnetIds := expandKeyVaultNetworkAcls(networkAclsRaw)
+			// before creating check to see if the key vault exists in the soft delete state
+			softDeletedKeyVault, err := client.GetDeleted(ctx, id.Name, location)
+			if err != nil {
+				// If Terraform lacks permission to read at the Subscription we'll get 409, not 404
+				if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
+					return fmt.Errorf("checking for the presence of an existing Soft-Deleted Key Vault %q (Location %q): %+v", id.Name, location, err)
+				}
+			}
 
-	sku := keyvault.Sku{
-		Family: &armKeyVaultSkuFamily,
-		Name:   keyvault.SkuName(d.Get("sku_name").(string)),
-	}
+			// if so, does the user want us to recover it?
 
-	parameters := keyvault.VaultCreateOrUpdateParameters{
-		Location: &location,
-		Properties: &keyvault.VaultProperties{
-			TenantID:                     &tenantUUID,
-			Sku:                          &sku,
-			AccessPolicies:               accessPolicies,
-			EnabledForDeployment:         &enabledForDeployment,
-			EnabledForDiskEncryption:     &enabledForDiskEncryption,
-			EnabledForTemplateDeployment: &enabledForTemplateDeployment,
-			EnableRbacAuthorization:      &enableRbacAuthorization,
-			NetworkAcls:                  networkAcls,
-
-			// @tombuildsstuff: as of 2020-12-15 this is now defaulted on, and appears to be so in all regions
-			// This has been confirmed in Azure Public and Azure China - but I couldn't find any more
-			// documentation with further details
-			EnableSoftDelete: utils.Bool(true),
-		},
-		Tags: tags.Expand(t),
-	}
+			recoverSoftDeletedKeyVault := false
+			if !utils.ResponseWasNotFound(softDeletedKeyVault.Response) && !utils.ResponseWasForbidden(softDeletedKeyVault.Response) {
+				if !metadata.Client.Features.KeyVault.RecoverSoftDeletedKeyVaults {
+					// this exists but the users opted out so they must import this it out-of-band
+					return fmt.Errorf(optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(id.Name, location))
+				}
 
-	if d.Get("public_network_access_enabled").(bool) {
-		parameters.Properties.PublicNetworkAccess = utils.String("Enabled")
-	} else {
-		parameters.Properties.PublicNetworkAccess = utils.String("Disabled")
-	}
+				recoverSoftDeletedKeyVault = true
+			}
 
-	if purgeProtectionEnabled := d.Get("purge_protection_enabled").(bool); purgeProtectionEnabled {
-		parameters.Properties.EnablePurgeProtection = utils.Bool(purgeProtectionEnabled)
-	}
+			tenantUUID := uuid.FromStringOrNil(model.TenantId)
 
-	if v := d.Get("soft_delete_retention_days"); v != 90 {
-		parameters.Properties.SoftDeleteRetentionInDays = utils.Int32(int32(v.(int)))
-	}
+			if err := resolveAccessPolicyObjectIds(ctx, metadata.Client.KeyVault, model.AccessPolicy); err != nil {
+				return err
+			}
+			accessPolicies := expandAccessPolicies(model.AccessPolicy)
 
-	if recoverSoftDeletedKeyVault {
-		parameters.Properties.CreateMode = keyvault.CreateModeRecover
-	}
+			networkAcls, subnetIds := expandKeyVaultNetworkAcls(model.NetworkAcls)
 
-	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-	virtualNetworkNames := make([]string, 0)
-	for _, v := range subnetIds {
-		id, err := networkParse.SubnetIDInsensitively(v)
-		if err != nil {
-			return err
-		}
-		if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-			virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
-		}
-	}
+			sku := keyvault.Sku{
+				Family: &armKeyVaultSkuFamily,
+				Name:   keyvault.SkuName(model.SkuName),
+			}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			parameters := keyvault.VaultCreateOrUpdateParameters{
+				Location: &location,
+				Properties: &keyvault.VaultProperties{
+					TenantID:                     &tenantUUID,
+					Sku:                          &sku,
+					AccessPolicies:               accessPolicies,
+					EnabledForDeployment:         utils.Bool(model.EnabledForDeployment),
+					EnabledForDiskEncryption:     utils.Bool(model.EnabledForDiskEncryption),
+					EnabledForTemplateDeployment: utils.Bool(model.EnabledForTemplateDeployment),
+					EnableRbacAuthorization:      utils.Bool(model.EnableRbacAuthorization),
+					NetworkAcls:                  networkAcls,
+
+					// @tombuildsstuff: as of 2020-12-15 this is now defaulted on, and appears to be so in all regions
+					// This has been confirmed in Azure Public and Azure China - but I couldn't find any more
+					// documentation with further details
+					EnableSoftDelete: utils.Bool(true),
+				},
+				Tags: tags.FromTypedObject(model.Tags),
+			}
 
-	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
-	if err != nil {
-		return fmt.Errorf("creating %s: %+v", id, err)
-	}
-	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
-		return fmt.Errorf("waiting for creation/update of %q: %+v", id, err)
-	}
+			if model.PublicNetworkAccessEnabled {
+				parameters.Properties.PublicNetworkAccess = utils.String("Enabled")
+			} else {
+				parameters.Properties.PublicNetworkAccess = utils.String("Disabled")
+			}
 
-	read, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		return fmt.Errorf("retrieving %s: %+v", id, err)
-	}
-	if read.Properties == nil || read.Properties.VaultURI == nil {
-		return fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", id)
-	}
-	d.SetId(id.ID())
-	meta.(*clients.Client).KeyVault.AddToCache(id, *read.Properties.VaultURI)
-
-	if props := read.Properties; props != nil {
-		if vault := props.VaultURI; vault != nil {
-			log.Printf("[DEBUG] Waiting for %s to become available", id)
-			stateConf := &pluginsdk.StateChangeConf{
-				Pending:                   []string{"pending"},
-				Target:                    []string{"available"},
-				Refresh:                   keyVaultRefreshFunc(*vault),
-				Delay:                     30 * time.Second,
-				PollInterval:              10 * time.Second,
-				ContinuousTargetOccurence: 10,
-				Timeout:                   d.Timeout(pluginsdk.TimeoutCreate),
+			if model.PurgeProtectionEnabled {
+				parameters.Properties.EnablePurgeProtection = utils.Bool(model.PurgeProtectionEnabled)
 			}
 
-			if _, err := stateConf.WaitForStateContext(ctx); err != nil {
-				return fmt.Errorf("waiting for %s to become available: %s", id, err)
+			if model.SoftDeleteRetentionDays != 90 {
+				parameters.Properties.SoftDeleteRetentionInDays = utils.Int32(int32(model.SoftDeleteRetentionDays))
 			}
-		}
-	}
 
-	if v, ok := d.GetOk("contact"); ok {
-		contacts := KeyVaultMgmt.Contacts{
-			ContactList: expandKeyVaultCertificateContactList(v.(*pluginsdk.Set).List()),
-		}
-		if read.Properties == nil || read.Properties.VaultURI == nil {
-			return fmt.Errorf("failed to get vault base url for %s: %s", id, err)
-		}
-		if _, err := dataPlaneClient.SetCertificateContacts(ctx, *read.Properties.VaultURI, contacts); err != nil {
-			return fmt.Errorf("failed to set Contacts for %s: %+v", id, err)
-		}
-	}
+			if recoverSoftDeletedKeyVault {
+				parameters.Properties.CreateMode = keyvault.CreateModeRecover
+			}
 
-	return resourceKeyVaultRead(d, meta)
-}
+			// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
+			virtualNetworkNames := make([]string, 0)
+			for _, v := range subnetIds {
+				id, err := networkParse.SubnetIDInsensitively(v)
+				if err != nil {
+					return err
+				}
+				if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
+					virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
+				}
+			}
 
-func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).KeyVault.VaultsClient
-	managementClient := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+			locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
-	id, err := parse.VaultID(d.Id())
-	if err != nil {
-		return err
-	}
+			future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for creation/update of %q: %+v", id, err)
+			}
 
-	// Locking this resource so we don't make modifications to it at the same time if there is a
-	// key vault access policy trying to update it as well
-	locks.ByName(id.Name, keyVaultResourceName)
-	defer locks.UnlockByName(id.Name, keyVaultResourceName)
+			read, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if read.Properties == nil || read.Properties.VaultURI == nil {
+				return fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", id)
+			}
+			metadata.SetID(id)
+			metadata.Client.KeyVault.AddToCache(id, *read.Properties.VaultURI)
 
-	d.Partial(true)
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return fmt.Errorf("could not retrieve context deadline for %s", id)
+			}
 
-	// first pull the existing key vault since we need to lock on several bits of its information
-	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		return fmt.Errorf("retrieving %s: %+v", *id, err)
-	}
-	if existing.Properties == nil {
-		return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			metadata.Logger.Infof("Waiting for %s to become available", id)
+			// the Vault URI isn't an operation in its own right, so instead we check that Secrets can be listed
+			readinessUri := fmt.Sprintf("%s/secrets", strings.TrimSuffix(*read.Properties.VaultURI, "/"))
+			if err := waitForKeyVaultReadiness(ctx, metadata.Client, readinessUri, time.Until(deadline)); err != nil {
+				return fmt.Errorf("waiting for %s to become available: %s", id, err)
+			}
+
+			if len(model.Contact) > 0 {
+				contacts := KeyVaultMgmt.Contacts{
+					ContactList: expandKeyVaultCertificateContactsContact(model.Contact),
+				}
+				if _, err := dataPlaneClient.SetCertificateContacts(ctx, *read.Properties.VaultURI, contacts); err != nil {
+					return fmt.Errorf("failed to set Contacts for %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+		Timeout: 30 * time.Minute,
 	}
+}
 
-	update := keyvault.VaultPatchParameters{}
+func (r KeyVaultResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.VaultsClient
+			managementClient := metadata.Client.KeyVault.ManagementClient
 
-	if d.HasChange("access_policy") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			id, err := parse.VaultID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
 
-		policiesRaw := d.Get("access_policy").([]interface{})
-		accessPolicies := expandAccessPolicies(policiesRaw)
-		update.Properties.AccessPolicies = accessPolicies
-	}
+			// the principal fields within the `access_policy` blocks aren't returned by the API
+			// so we need the existing values from the state
+			var existing KeyVaultResourceModel
+			if err := metadata.Decode(&existing); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	if d.HasChange("enabled_for_deployment") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if resp.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
+			if resp.Properties.VaultURI == nil {
+				return fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", *id)
+			}
 
-		update.Properties.EnabledForDeployment = utils.Bool(d.Get("enabled_for_deployment").(bool))
-	}
+			props := *resp.Properties
+			metadata.Client.KeyVault.AddToCache(*id, *resp.Properties.VaultURI)
+
+			state := KeyVaultResourceModel{
+				Name:                         id.Name,
+				ResourceGroupName:            id.ResourceGroup,
+				Location:                     location.NormalizeNilable(resp.Location),
+				TenantId:                     props.TenantID.String(),
+				EnabledForDeployment:         utils.NormaliseNilableBool(props.EnabledForDeployment),
+				EnabledForDiskEncryption:     utils.NormaliseNilableBool(props.EnabledForDiskEncryption),
+				EnabledForTemplateDeployment: utils.NormaliseNilableBool(props.EnabledForTemplateDeployment),
+				EnableRbacAuthorization:      utils.NormaliseNilableBool(props.EnableRbacAuthorization),
+				PurgeProtectionEnabled:       utils.NormaliseNilableBool(props.EnablePurgeProtection),
+				PublicNetworkAccessEnabled:   existing.PublicNetworkAccessEnabled,
+				VaultUri:                     *props.VaultURI,
+				Tags:                         tags.ToTypedObject(resp.Tags),
+			}
 
-	if d.HasChange("enabled_for_disk_encryption") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			if v := props.PublicNetworkAccess; v != nil {
+				state.PublicNetworkAccessEnabled = *v == "Enabled"
+			}
 
-		update.Properties.EnabledForDiskEncryption = utils.Bool(d.Get("enabled_for_disk_encryption").(bool))
-	}
+			// @tombuildsstuff: the API doesn't return this field if it's not configured
+			// however https://docs.microsoft.com/en-us/azure/key-vault/general/soft-delete-overview
+			// defaults this to 90 days, as such we're going to have to assume that for the moment
+			// in lieu of anything being returned
+			state.SoftDeleteRetentionDays = 90
+			if props.SoftDeleteRetentionInDays != nil && *props.SoftDeleteRetentionInDays != 0 {
+				state.SoftDeleteRetentionDays = int(*props.SoftDeleteRetentionInDays)
+			}
 
-	if d.HasChange("enabled_for_template_deployment") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			if sku := props.Sku; sku != nil {
+				// the Azure API is inconsistent here, so rewrite this into the casing we expect
+				for _, v := range keyvault.PossibleSkuNameValues() {
+					if strings.EqualFold(string(v), string(sku.Name)) {
+						state.SkuName = string(v)
+					}
+				}
+			}
 
-		update.Properties.EnabledForTemplateDeployment = utils.Bool(d.Get("enabled_for_template_deployment").(bool))
-	}
+			state.NetworkAcls = flattenKeyVaultNetworkAcls(props.NetworkAcls)
 
-	if d.HasChange("enable_rbac_authorization") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			state.AccessPolicy = flattenAccessPolicies(props.AccessPolicies)
+			setAccessPolicyPrincipalFields(state.AccessPolicy, existing.AccessPolicy)
 
-		update.Properties.EnableRbacAuthorization = utils.Bool(d.Get("enable_rbac_authorization").(bool))
-	}
+			contactsResp, err := managementClient.GetCertificateContacts(ctx, *props.VaultURI)
+			if err != nil {
+				if !utils.ResponseWasForbidden(contactsResp.Response) && !utils.ResponseWasNotFound(contactsResp.Response) {
+					return fmt.Errorf("retrieving `contact` for KeyVault: %+v", err)
+				}
+			}
+			state.Contact = flattenKeyVaultCertificateContactsContact(contactsResp.ContactList)
 
-	if d.HasChange("network_acls") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			return metadata.Encode(&state)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
 
-		networkAclsRaw := d.Get("network_acls").([]interface{})
-		networkAcls, subnetIds := expandKeyVaultNetworkAcls(networkAclsRaw)
+func (r KeyVaultResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.VaultsClient
+			managementClient := metadata.Client.KeyVault.ManagementClient
 
-		// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-		virtualNetworkNames := make([]string, 0)
-		for _, v := range subnetIds {
-			id, err := networkParse.SubnetIDInsensitively(v)
+			id, err := parse.VaultID(metadata.ResourceData.Id())
 			if err != nil {
 				return err
 			}
 
-			if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-				virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
+			var model KeyVaultResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
 			}
-		}
 
-		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			// Locking this resource so we don't make modifications to it at the same time if there is a
+			// key vault access policy trying to update it as well
+			locks.ByName(id.Name, keyVaultResourceName)
+			defer locks.UnlockByName(id.Name, keyVaultResourceName)
 
-		update.Properties.NetworkAcls = networkAcls
-	}
+			metadata.ResourceData.Partial(true)
 
-	if d.HasChange("purge_protection_enabled") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+			// first pull the existing key vault since we need to lock on several bits of its information
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
 
-		newValue := d.Get("purge_protection_enabled").(bool)
+			update := keyvault.VaultPatchParameters{}
 
-		// existing.Properties guaranteed non-nil above
-		oldValue := false
-		if existing.Properties.EnablePurgeProtection != nil {
-			oldValue = *existing.Properties.EnablePurgeProtection
-		}
-
-		// whilst this should have got caught in the customizeDiff this won't work if that fields interpolated
-		// hence the double-checking here
-		if oldValue && !newValue {
-			return fmt.Errorf("updating %s: once Purge Protection has been Enabled it's not possible to disable it", *id)
-		}
+			if metadata.ResourceData.HasChange("access_policy") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-		update.Properties.EnablePurgeProtection = utils.Bool(newValue)
+				if err := resolveAccessPolicyObjectIds(ctx, metadata.Client.KeyVault, model.AccessPolicy); err != nil {
+					return err
+				}
+				update.Properties.AccessPolicies = expandAccessPolicies(model.AccessPolicy)
+			}
 
-		if newValue {
-			// When the KV was created with a version prior to v2.42 and the `soft_delete_enabled` is set to false, setting `purge_protection_enabled` to `true` would not work when updating KV with v2.42 or later of terraform provider.
-			// This is because the `purge_protection_enabled` only works when soft delete is enabled.
-			// Since version v2.42 of the Azure Provider and later force the value of `soft_delete_enabled` to be true, we should set `EnableSoftDelete` to true when `purge_protection_enabled` is enabled to make sure it works in this case.
-			update.Properties.EnableSoftDelete = utils.Bool(true)
-		}
-	}
+			if metadata.ResourceData.HasChange("enabled_for_deployment") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	if d.HasChange("public_network_access_enabled") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+				update.Properties.EnabledForDeployment = utils.Bool(model.EnabledForDeployment)
+			}
 
-		if d.Get("public_network_access_enabled").(bool) {
-			update.Properties.PublicNetworkAccess = utils.String("Enabled")
-		} else {
-			update.Properties.PublicNetworkAccess = utils.String("Disabled")
-		}
-	}
+			if metadata.ResourceData.HasChange("enabled_for_disk_encryption") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	if d.HasChange("sku_name") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+				update.Properties.EnabledForDiskEncryption = utils.Bool(model.EnabledForDiskEncryption)
+			}
 
-		update.Properties.Sku = &keyvault.Sku{
-			Family: &armKeyVaultSkuFamily,
-			Name:   keyvault.SkuName(d.Get("sku_name").(string)),
-		}
-	}
+			if metadata.ResourceData.HasChange("enabled_for_template_deployment") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	if d.HasChange("soft_delete_retention_days") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+				update.Properties.EnabledForTemplateDeployment = utils.Bool(model.EnabledForTemplateDeployment)
+			}
 
-		// existing.Properties guaranteed non-nil above
-		var oldValue int32 = 0
-		if existing.Properties.SoftDeleteRetentionInDays != nil {
-			oldValue = *existing.Properties.SoftDeleteRetentionInDays
-		}
+			if metadata.ResourceData.HasChange("enable_rbac_authorization") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-		// whilst this should have got caught in the customizeDiff this won't work if that fields interpolated
-		// hence the double-checking here
-		if oldValue != 0 {
-			// Code="BadRequest" Message="The property \"softDeleteRetentionInDays\" has been set already and it can't be modified."
-			return fmt.Errorf("updating %s: once `soft_delete_retention_days` has been configured it cannot be modified", *id)
-		}
+				update.Properties.EnableRbacAuthorization = utils.Bool(model.EnableRbacAuthorization)
+			}
 
-		update.Properties.SoftDeleteRetentionInDays = utils.Int32(int32(d.Get("soft_delete_retention_days").(int)))
-	}
+			if metadata.ResourceData.HasChange("network_acls") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	if d.HasChange("tenant_id") {
-		if update.Properties == nil {
-			update.Properties = &keyvault.VaultPatchProperties{}
-		}
+				networkAcls, subnetIds := expandKeyVaultNetworkAcls(model.NetworkAcls)
 
-		tenantUUID := uuid.FromStringOrNil(d.Get("tenant_id").(string))
-		update.Properties.TenantID = &tenantUUID
-	}
+				// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
+				virtualNetworkNames := make([]string, 0)
+				for _, v := range subnetIds {
+					id, err := networkParse.SubnetIDInsensitively(v)
+					if err != nil {
+						return err
+					}
 
-	if d.HasChange("tags") {
-		t := d.Get("tags").(map[string]interface{})
-		update.Tags = tags.Expand(t)
-	}
+					if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
+						virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
+					}
+				}
 
-	if _, err := client.Update(ctx, id.ResourceGroup, id.Name, update); err != nil {
-		return fmt.Errorf("updating %s: %+v", *id, err)
-	}
+				locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+				defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
-	if d.HasChange("contact") {
-		contacts := KeyVaultMgmt.Contacts{
-			ContactList: expandKeyVaultCertificateContactList(d.Get("contact").(*pluginsdk.Set).List()),
-		}
-		if existing.Properties == nil || existing.Properties.VaultURI == nil {
-			return fmt.Errorf("failed to get vault base url for %s: %s", *id, err)
-		}
+				update.Properties.NetworkAcls = networkAcls
+			}
 
-		var err error
-		if len(*contacts.ContactList) == 0 {
-			_, err = managementClient.DeleteCertificateContacts(ctx, *existing.Properties.VaultURI)
-		} else {
-			_, err = managementClient.SetCertificateContacts(ctx, *existing.Properties.VaultURI, contacts)
-		}
+			if metadata.ResourceData.HasChange("purge_protection_enabled") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-		if err != nil {
-			return fmt.Errorf("setting Contacts for %s: %+v", *id, err)
-		}
-	}
+				newValue := model.PurgeProtectionEnabled
 
-	d.Partial(false)
+				// existing.Properties guaranteed non-nil above
+				oldValue := false
+				if existing.Properties.EnablePurgeProtection != nil {
+					oldValue = *existing.Properties.EnablePurgeProtection
+				}
 
-	return resourceKeyVaultRead(d, meta)
-}
+				// whilst this should have got caught in the customizeDiff this won't work if that fields interpolated
+				// hence the double-checking here
+				if oldValue && !newValue {
+					return fmt.Errorf("updating %s: once Purge Protection has been Enabled it's not possible to disable it", *id)
+				}
 
-func resourceKeyVaultRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).KeyVault.VaultsClient
-	managementClient := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+				update.Properties.EnablePurgeProtection = utils.Bool(newValue)
 
-	id, err := parse.VaultID(d.Id())
-	if err != nil {
-		return err
-	}
+				if newValue {
+					// When the KV was created with a version prior to v2.42 and the `soft_delete_enabled` is set to false, setting `purge_protection_enabled` to `true` would not work when updating KV with v2.42 or later of terraform provider.
+					// This is because the `purge_protection_enabled` only works when soft delete is enabled.
+					// Since version v2.42 of the Azure Provider and later force the value of `soft_delete_enabled` to be true, we should set `EnableSoftDelete` to true when `purge_protection_enabled` is enabled to make sure it works in this case.
+					update.Properties.EnableSoftDelete = utils.Bool(true)
+				}
+			}
 
-	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			log.Printf("[DEBUG] %s was not found - removing from state!", *id)
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("retrieving %s: %+v", *id, err)
-	}
-	if resp.Properties == nil {
-		return fmt.Errorf("retrieving %s: `properties` was nil", *id)
-	}
-	if resp.Properties.VaultURI == nil {
-		return fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", *id)
-	}
+			if metadata.ResourceData.HasChange("public_network_access_enabled") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	props := *resp.Properties
-	meta.(*clients.Client).KeyVault.AddToCache(*id, *resp.Properties.VaultURI)
-
-	d.Set("name", id.Name)
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("location", location.NormalizeNilable(resp.Location))
-
-	d.Set("tenant_id", props.TenantID.String())
-	d.Set("enabled_for_deployment", props.EnabledForDeployment)
-	d.Set("enabled_for_disk_encryption", props.EnabledForDiskEncryption)
-	d.Set("enabled_for_template_deployment", props.EnabledForTemplateDeployment)
-	d.Set("enable_rbac_authorization", props.EnableRbacAuthorization)
-	d.Set("purge_protection_enabled", props.EnablePurgeProtection)
-	if v := props.PublicNetworkAccess; v != nil {
-		d.Set("public_network_access_enabled", *v == "Enabled")
-	}
-	d.Set("vault_uri", props.VaultURI)
-
-	// @tombuildsstuff: the API doesn't return this field if it's not configured
-	// however https://docs.microsoft.com/en-us/azure/key-vault/general/soft-delete-overview
-	// defaults this to 90 days, as such we're going to have to assume that for the moment
-	// in lieu of anything being returned
-	softDeleteRetentionDays := 90
-	if props.SoftDeleteRetentionInDays != nil && *props.SoftDeleteRetentionInDays != 0 {
-		softDeleteRetentionDays = int(*props.SoftDeleteRetentionInDays)
-	}
-	d.Set("soft_delete_retention_days", softDeleteRetentionDays)
-
-	skuName := ""
-	if sku := props.Sku; sku != nil {
-		// the Azure API is inconsistent here, so rewrite this into the casing we expect
-		for _, v := range keyvault.PossibleSkuNameValues() {
-			if strings.EqualFold(string(v), string(sku.Name)) {
-				skuName = string(v)
+				if model.PublicNetworkAccessEnabled {
+					update.Properties.PublicNetworkAccess = utils.String("Enabled")
+				} else {
+					update.Properties.PublicNetworkAccess = utils.String("Disabled")
+				}
 			}
-		}
-	}
-	d.Set("sku_name", skuName)
 
-	if err := d.Set("network_acls", flattenKeyVaultNetworkAcls(props.NetworkAcls)); err != nil {
-		return fmt.Errorf("setting `network_acls` for KeyVault %q: %+v", *resp.Name, err)
-	}
-
-	flattenedPolicies := flattenAccessPolicies(props.AccessPolicies)
-	if err := d.Set("access_policy", flattenedPolicies); err != nil {
-		return fmt.Errorf("setting `access_policy` for KeyVault %q: %+v", *resp.Name, err)
-	}
+			if metadata.ResourceData.HasChange("sku_name") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-	contactsResp, err := managementClient.GetCertificateContacts(ctx, *props.VaultURI)
-	if err != nil {
-		if !utils.ResponseWasForbidden(contactsResp.Response) && !utils.ResponseWasNotFound(contactsResp.Response) {
-			return fmt.Errorf("retrieving `contact` for KeyVault: %+v", err)
-		}
-	}
-	if err := d.Set("contact", flattenKeyVaultCertificateContactList(contactsResp)); err != nil {
-		return fmt.Errorf("setting `contact` for KeyVault: %+v", err)
-	}
+				update.Properties.Sku = &keyvault.Sku{
+					Family: &armKeyVaultSkuFamily,
+					Name:   keyvault.SkuName(model.SkuName),
+				}
+			}
 
-	return tags.FlattenAndSet(d, resp.Tags)
-}
+			if metadata.ResourceData.HasChange("soft_delete_retention_days") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).KeyVault.VaultsClient
-	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+				// existing.Properties guaranteed non-nil above
+				var oldValue int32 = 0
+				if existing.Properties.SoftDeleteRetentionInDays != nil {
+					oldValue = *existing.Properties.SoftDeleteRetentionInDays
+				}
 
-	id, err := parse.VaultID(d.Id())
-	if err != nil {
-		return err
-	}
+				// whilst this should have got caught in the customizeDiff this won't work if that fields interpolated
+				// hence the double-checking here
+				if oldValue != 0 {
+					// Code="BadRequest" Message="The property \"softDeleteRetentionInDays\" has been set already and it can't be modified."
+					return fmt.Errorf("updating %s: once `soft_delete_retention_days` has been configured it cannot be modified", *id)
+				}
 
-	locks.ByName(id.Name, keyVaultResourceName)
-	defer locks.UnlockByName(id.Name, keyVaultResourceName)
+				update.Properties.SoftDeleteRetentionInDays = utils.Int32(int32(model.SoftDeleteRetentionDays))
+			}
 
-	read, err := client.Get(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		if utils.ResponseWasNotFound(read.Response) {
-			return nil
-		}
+			if metadata.ResourceData.HasChange("tenant_id") {
+				if update.Properties == nil {
+					update.Properties = &keyvault.VaultPatchProperties{}
+				}
 
-		return fmt.Errorf("retrieving %s: %+v", *id, err)
-	}
+				tenantUUID := uuid.FromStringOrNil(model.TenantId)
+				update.Properties.TenantID = &tenantUUID
+			}
 
-	if read.Properties == nil {
-		return fmt.Errorf("retrieving %q: `properties` was nil", *id)
-	}
-	if read.Location == nil {
-		return fmt.Errorf("retrieving %q: `location` was nil", *id)
-	}
+			if metadata.ResourceData.HasChange("tags") {
+				update.Tags = tags.FromTypedObject(model.Tags)
+			}
 
-	// Check to see if purge protection is enabled or not...
-	purgeProtectionEnabled := false
-	if ppe := read.Properties.EnablePurgeProtection; ppe != nil {
-		purgeProtectionEnabled = *ppe
-	}
-	softDeleteEnabled := false
-	if sde := read.Properties.EnableSoftDelete; sde != nil {
-		softDeleteEnabled = *sde
-	}
+			if _, err := client.Update(ctx, id.ResourceGroup, id.Name, update); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
 
-	// ensure we lock on the latest network names, to ensure we handle Azure's networking layer being limited to one change at a time
-	virtualNetworkNames := make([]string, 0)
-	if props := read.Properties; props != nil {
-		if acls := props.NetworkAcls; acls != nil {
-			if rules := acls.VirtualNetworkRules; rules != nil {
-				for _, v := range *rules {
-					if v.ID == nil {
-						continue
-					}
+			if metadata.ResourceData.HasChange("contact") {
+				contacts := KeyVaultMgmt.Contacts{
+					ContactList: expandKeyVaultCertificateContactsContact(model.Contact),
+				}
+				if existing.Properties.VaultURI == nil {
+					return fmt.Errorf("failed to get vault base url for %s: `properties.VaultUri` was nil", *id)
+				}
 
-					subnetId, err := networkParse.SubnetIDInsensitively(*v.ID)
-					if err != nil {
-						return err
-					}
+				var err error
+				if len(*contacts.ContactList) == 0 {
+					_, err = managementClient.DeleteCertificateContacts(ctx, *existing.Properties.VaultURI)
+				} else {
+					_, err = managementClient.SetCertificateContacts(ctx, *existing.Properties.VaultURI, contacts)
+				}
 
-					if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
-						virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
-					}
+				if err != nil {
+					return fmt.Errorf("setting Contacts for %s: %+v", *id, err)
 				}
 			}
-		}
-	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			metadata.ResourceData.Partial(false)
 
-	resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
-	if err != nil {
-		if !response.WasNotFound(resp.Response) {
-			return fmt.Errorf("retrieving %s: %+v", *id, err)
-		}
+			return nil
+		},
+		Timeout: 30 * time.Minute,
 	}
+}
+
+func (r KeyVaultResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.KeyVault.VaultsClient
 
-	// Purge the soft deleted key vault permanently if the feature flag is enabled
-	if meta.(*clients.Client).Features.KeyVault.PurgeSoftDeleteOnDestroy && softDeleteEnabled {
-		// KeyVaults with Purge Protection Enabled cannot be deleted unless done by Azure
-		if purgeProtectionEnabled {
-			deletedInfo, err := getSoftDeletedStateForKeyVault(ctx, client, id.Name, *read.Location)
+			id, err := parse.VaultID(metadata.ResourceData.Id())
 			if err != nil {
-				return fmt.Errorf("retrieving the Deletion Details for %s: %+v", *id, err)
+				return err
 			}
 
-			// in the future it'd be nice to raise a warning, but this is the best we can do for now
-			if deletedInfo != nil {
-				log.Printf("[DEBUG] The Key Vault %q has Purge Protection Enabled and was deleted on %q. Azure will purge this on %q", id.Name, deletedInfo.deleteDate, deletedInfo.purgeDate)
-			} else {
-				log.Printf("[DEBUG] The Key Vault %q has Purge Protection Enabled and will be purged automatically by Azure", id.Name)
+			locks.ByName(id.Name, keyVaultResourceName)
+			defer locks.UnlockByName(id.Name, keyVaultResourceName)
+
+			read, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(read.Response) {
+					return nil
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
 			}
-			return nil
-		}
 
-		log.Printf("[DEBUG] KeyVault %q marked for purge - executing purge", id.Name)
-		future, err := client.PurgeDeleted(ctx, id.Name, *read.Location)
-		if err != nil {
-			return err
-		}
+			if read.Properties == nil {
+				return fmt.Errorf("retrieving %q: `properties` was nil", *id)
+			}
+			if read.Location == nil {
+				return fmt.Errorf("retrieving %q: `location` was nil", *id)
+			}
 
-		log.Printf("[DEBUG] Waiting for purge of KeyVault %q..", id.Name)
-		err = future.WaitForCompletionRef(ctx, client.Client)
-		if err != nil {
-			return fmt.Errorf("purging %s: %+v", *id, err)
-		}
-		log.Printf("[DEBUG] Purged KeyVault %q.", id.Name)
-	}
+			// Check to see if purge protection is enabled or not...
+			purgeProtectionEnabled := false
+			if ppe := read.Properties.EnablePurgeProtection; ppe != nil {
+				purgeProtectionEnabled = *ppe
+			}
+			softDeleteEnabled := false
+			if sde := read.Properties.EnableSoftDelete; sde != nil {
+				softDeleteEnabled = *sde
+			}
 
-	meta.(*clients.Client).KeyVault.Purge(*id)
+			// ensure we lock on the latest network names, to ensure we handle Azure's networking layer being limited to one change at a time
+			virtualNetworkNames := make([]string, 0)
+			if acls := read.Properties.NetworkAcls; acls != nil {
+				if rules := acls.VirtualNetworkRules; rules != nil {
+					for _, v := range *rules {
+						if v.ID == nil {
+							continue
+						}
+
+						subnetId, err := networkParse.SubnetIDInsensitively(*v.ID)
+						if err != nil {
+							return err
+						}
+
+						if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
+							virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
+						}
+					}
+				}
+			}
 
-	return nil
-}
+			locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
-func keyVaultRefreshFunc(vaultUri string) pluginsdk.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		log.Printf("[DEBUG] Checking to see if KeyVault %q is available..", vaultUri)
+			resp, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if !response.WasNotFound(resp.Response) {
+					return fmt.Errorf("retrieving %s: %+v", *id, err)
+				}
+			}
 
-		client := &http.Client{
-			Transport: &http.Transport{
-				Proxy: http.ProxyFromEnvironment,
-			},
-		}
+			// Purge the soft deleted key vault permanently if the feature flag is enabled
+			if metadata.Client.Features.KeyVault.PurgeSoftDeleteOnDestroy && softDeleteEnabled {
+				// KeyVaults with Purge Protection Enabled cannot be deleted unless done by Azure
+				if purgeProtectionEnabled {
+					deletedInfo, err := getSoftDeletedStateForKeyVault(ctx, client, id.Name, *read.Location)
+					if err != nil {
+						return fmt.Errorf("retrieving the Deletion Details for %s: %+v", *id, err)
+					}
 
-		conn, err := client.Get(vaultUri)
-		if err != nil {
-			log.Printf("[DEBUG] Didn't find KeyVault at %q", vaultUri)
-			return nil, "pending", fmt.Errorf("connecting to %q: %s", vaultUri, err)
-		}
+					// in the future it'd be nice to raise a warning, but this is the best we can do for now
+					if deletedInfo != nil {
+						metadata.Logger.Infof("The Key Vault %q has Purge Protection Enabled and was deleted on %q. Azure will purge this on %q", id.Name, deletedInfo.deleteDate, deletedInfo.purgeDate)
+					} else {
+						metadata.Logger.Infof("The Key Vault %q has Purge Protection Enabled and will be purged automatically by Azure", id.Name)
+					}
+					return nil
+				}
+
+				metadata.Logger.Infof("KeyVault %q marked for purge - executing purge", id.Name)
+				future, err := client.PurgeDeleted(ctx, id.Name, *read.Location)
+				if err != nil {
+					return err
+				}
+
+				metadata.Logger.Infof("Waiting for purge of KeyVault %q..", id.Name)
+				if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+					return fmt.Errorf("purging %s: %+v", *id, err)
+				}
+				metadata.Logger.Infof("Purged KeyVault %q.", id.Name)
+			}
 
-		defer conn.Body.Close()
+			metadata.Client.KeyVault.Purge(*id)
 
-		log.Printf("[DEBUG] Found KeyVault at %q", vaultUri)
-		return "available", "available", nil
+			return nil
+		},
+		Timeout: 30 * time.Minute,
 	}
 }
 
-func expandKeyVaultNetworkAcls(input []interface{}) (*keyvault.NetworkRuleSet, []string) {
+func expandKeyVaultNetworkAcls(input []KeyVaultNetworkAclsModel) (*keyvault.NetworkRuleSet, []string) {
 	subnetIds := make([]string, 0)
 	if len(input) == 0 {
 		return nil, subnetIds
 	}
 
-	v := input[0].(map[string]interface{})
-
-	bypass := v["bypass"].(string)
-	defaultAction := v["default_action"].(string)
+	v := input[0]
 
-	ipRulesRaw := v["ip_rules"].(*pluginsdk.Set)
 	ipRules := make([]keyvault.IPRule, 0)
-
-	for _, v := range ipRulesRaw.List() {
+	for _, ipRule := range v.IPRules {
 		rule := keyvault.IPRule{
-			Value: utils.String(v.(string)),
+			Value: utils.String(ipRule),
 		}
 		ipRules = append(ipRules, rule)
 	}
 
-	networkRulesRaw := v["virtual_network_subnet_ids"].(*pluginsdk.Set)
 	networkRules := make([]keyvault.VirtualNetworkRule, 0)
-	for _, v := range networkRulesRaw.List() {
-		rawId := v.(string)
+	for _, rawId := range v.VirtualNetworkSubnetIds {
 		subnetIds = append(subnetIds, rawId)
 		rule := keyvault.VirtualNetworkRule{
 			ID: utils.String(rawId),
@@ -876,50 +919,32 @@ func expandKeyVaultNetworkAcls(input []interface{}) (*keyvault.NetworkRuleSet, [
 	}
 
 	ruleSet := keyvault.NetworkRuleSet{
-		Bypass:              keyvault.NetworkRuleBypassOptions(bypass),
-		DefaultAction:       keyvault.NetworkRuleAction(defaultAction),
+		Bypass:              keyvault.NetworkRuleBypassOptions(v.Bypass),
+		DefaultAction:       keyvault.NetworkRuleAction(v.DefaultAction),
 		IPRules:             &ipRules,
 		VirtualNetworkRules: &networkRules,
 	}
 	return &ruleSet, subnetIds
 }
 
-func expandKeyVaultCertificateContactList(input []interface{}) *[]KeyVaultMgmt.Contact {
-	results := make([]KeyVaultMgmt.Contact, 0)
-	if len(input) == 0 || input[0] == nil {
-		return &results
-	}
-
-	for _, item := range input {
-		v := item.(map[string]interface{})
-		results = append(results, KeyVaultMgmt.Contact{
-			Name:         utils.String(v["name"].(string)),
-			EmailAddress: utils.String(v["email"].(string)),
-			Phone:        utils.String(v["phone"].(string)),
-		})
-	}
-
-	return &results
-}
-
-func flattenKeyVaultNetworkAcls(input *keyvault.NetworkRuleSet) []interface{} {
+func flattenKeyVaultNetworkAcls(input *keyvault.NetworkRuleSet) []KeyVaultNetworkAclsModel {
 	if input == nil {
-		return []interface{}{
-			map[string]interface{}{
-				"bypass":                     string(keyvault.NetworkRuleBypassOptionsAzureServices),
-				"default_action":             string(keyvault.NetworkRuleActionAllow),
-				"ip_rules":                   pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
-				"virtual_network_subnet_ids": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+		return []KeyVaultNetworkAclsModel{
+			{
+				Bypass:                  string(keyvault.NetworkRuleBypassOptionsAzureServices),
+				DefaultAction:           string(keyvault.NetworkRuleActionAllow),
+				IPRules:                 []string{},
+				VirtualNetworkSubnetIds: []string{},
 			},
 		}
 	}
 
-	output := make(map[string]interface{})
-
-	output["bypass"] = string(input.Bypass)
-	output["default_action"] = string(input.DefaultAction)
+	output := KeyVaultNetworkAclsModel{
+		Bypass:        string(input.Bypass),
+		DefaultAction: string(input.DefaultAction),
+	}
 
-	ipRules := make([]interface{}, 0)
+	ipRules := make([]string, 0)
 	if input.IPRules != nil {
 		for _, v := range *input.IPRules {
 			if v.Value == nil {
@@ -929,9 +954,9 @@ func flattenKeyVaultNetworkAcls(input *keyvault.NetworkRuleSet) []interface{} {
 			ipRules = append(ipRules, *v.Value)
 		}
 	}
-	output["ip_rules"] = pluginsdk.NewSet(pluginsdk.HashString, ipRules)
+	output.IPRules = ipRules
 
-	virtualNetworkRules := make([]interface{}, 0)
+	virtualNetworkRules := make([]string, 0)
 	if input.VirtualNetworkRules != nil {
 		for _, v := range *input.VirtualNetworkRules {
 			if v.ID == nil {
@@ -947,41 +972,9 @@ func flattenKeyVaultNetworkAcls(input *keyvault.NetworkRuleSet) []interface{} {
 			virtualNetworkRules = append(virtualNetworkRules, id)
 		}
 	}
-	output["virtual_network_subnet_ids"] = pluginsdk.NewSet(pluginsdk.HashString, virtualNetworkRules)
-
-	return []interface{}{output}
-}
-
-func flattenKeyVaultCertificateContactList(input KeyVaultMgmt.Contacts) []interface{} {
-	results := make([]interface{}, 0)
-	if input.ContactList == nil {
-		return results
-	}
-
-	for _, contact := range *input.ContactList {
-		emailAddress := ""
-		if contact.EmailAddress != nil {
-			emailAddress = *contact.EmailAddress
-		}
-
-		name := ""
-		if contact.Name != nil {
-			name = *contact.Name
-		}
-
-		phone := ""
-		if contact.Phone != nil {
-			phone = *contact.Phone
-		}
-
-		results = append(results, map[string]interface{}{
-			"email": emailAddress,
-			"name":  name,
-			"phone": phone,
-		})
-	}
+	output.VirtualNetworkSubnetIds = virtualNetworkRules
 
-	return results
+	return []KeyVaultNetworkAclsModel{output}
 }
 
 func optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(name, location string) string {
@@ -0,0 +1,114 @@
+package keyvault_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+// these snapshots were generated from the untyped implementations of `customkv_key_vault` - the typed
+// implementations must expose exactly the same schema, else existing configurations/state would break
+
+func TestKeyVaultResource_schemaSnapshot(t *testing.T) {
+	provider, err := providerjson.ProviderFromRaw(providerjson.LoadData())
+	if err != nil {
+		t.Fatalf("loading provider schema: %+v", err)
+	}
+
+	resource, ok := provider.ResourcesMap["customkv_key_vault"]
+	if !ok {
+		t.Fatalf("the Resource `customkv_key_vault` was not registered")
+	}
+
+	compareSchemaSnapshot(t, "testdata/key_vault_resource_schema.json", resource)
+}
+
+func TestKeyVaultDataSource_schemaSnapshot(t *testing.T) {
+	provider, err := providerjson.ProviderFromRaw(providerjson.LoadData())
+	if err != nil {
+		t.Fatalf("loading provider schema: %+v", err)
+	}
+
+	dataSource, ok := provider.DataSourcesMap["customkv_key_vault"]
+	if !ok {
+		t.Fatalf("the Data Source `customkv_key_vault` was not registered")
+	}
+
+	compareSchemaSnapshot(t, "testdata/key_vault_data_source_schema.json", dataSource)
+}
+
+func TestKeyVaultResource_stateUpgraders(t *testing.T) {
+	resource, ok := providerjson.LoadData().ResourcesMap["customkv_key_vault"]
+	if !ok {
+		t.Fatalf("the Resource `customkv_key_vault` was not registered")
+	}
+
+	if resource.SchemaVersion != 2 {
+		t.Fatalf("expected the SchemaVersion to be 2 but got %d", resource.SchemaVersion)
+	}
+	if len(resource.StateUpgraders) != 2 {
+		t.Fatalf("expected 2 State Upgraders but got %d", len(resource.StateUpgraders))
+	}
+
+	state := map[string]interface{}{
+		"access_policy": []interface{}{
+			map[string]interface{}{
+				"certificate_permissions": []interface{}{"get"},
+				"key_permissions":         []interface{}{"get"},
+				"secret_permissions":      []interface{}{"all"},
+			},
+		},
+	}
+	for i, upgrader := range resource.StateUpgraders {
+		if upgrader.Version != i {
+			t.Fatalf("expected State Upgrader %d to be for version %d but got %d", i, i, upgrader.Version)
+		}
+
+		var err error
+		state, err = upgrader.Upgrade(context.TODO(), state, nil)
+		if err != nil {
+			t.Fatalf("upgrading state from version %d: %+v", upgrader.Version, err)
+		}
+		if state == nil {
+			t.Fatalf("upgrading state from version %d: state was nil", upgrader.Version)
+		}
+	}
+
+	policy := state["access_policy"].([]interface{})[0].(map[string]interface{})
+	if v := policy["secret_permissions"].([]string); len(v) != 8 {
+		t.Fatalf("expected `all` to be expanded into 8 secret permissions but got %+v", v)
+	}
+	if v := state["soft_delete_retention_days"]; v != 90 {
+		t.Fatalf("expected `soft_delete_retention_days` to be defaulted to 90 but got %+v", v)
+	}
+}
+
+func compareSchemaSnapshot(t *testing.T, snapshotPath string, actual providerjson.ResourceJSON) {
+	raw, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("reading snapshot %q: %+v", snapshotPath, err)
+	}
+
+	// round-trip the current schema through JSON so that both sides are compared in the same form
+	actualRaw, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatalf("marshalling schema: %+v", err)
+	}
+
+	var expected, current interface{}
+	if err := json.Unmarshal(raw, &expected); err != nil {
+		t.Fatalf("unmarshalling snapshot %q: %+v", snapshotPath, err)
+	}
+	if err := json.Unmarshal(actualRaw, &current); err != nil {
+		t.Fatalf("unmarshalling schema: %+v", err)
+	}
+
+	if !reflect.DeepEqual(expected, current) {
+		formatted, _ := json.MarshalIndent(current, "", "  ")
+		t.Fatalf("the schema differs from the snapshot %q - got:\n\n%s", snapshotPath, string(formatted))
+	}
+}
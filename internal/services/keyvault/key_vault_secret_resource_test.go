@@ -219,6 +219,18 @@ func TestAccKeyVaultSecret_purge(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultSecret_featuresPolicyViolation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.featuresPolicy(data),
+			ExpectError: regexp.MustCompile("`expiration_date` must be specified"),
+		},
+	})
+}
+
 func (KeyVaultSecretResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	client := clients.KeyVault.ManagementClient
 	keyVaultsClient := clients.KeyVault
@@ -417,6 +429,27 @@ resource "azurerm_key_vault_secret" "test" {
 `, purge, r.template(data), data.RandomString, value)
 }
 
+func (r KeyVaultSecretResource) featuresPolicy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {
+    key_vault {
+      require_expiration_date = true
+      required_tags           = ["owner"]
+    }
+  }
+}
+
+%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-%s"
+  value        = "rick-and-morty"
+  key_vault_id = azurerm_key_vault.test.id
+}
+`, r.template(data), data.RandomString)
+}
+
 func (KeyVaultSecretResource) withExternalAccessPolicy(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
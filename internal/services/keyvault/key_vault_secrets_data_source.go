@@ -1,118 +1,113 @@
 package keyvault
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
 
-func dataSourceKeyVaultSecrets() *pluginsdk.Resource {
-	return &pluginsdk.Resource{
-		Read: dataSourceKeyVaultSecretsRead,
+var _ sdk.DataSource = KeyVaultSecretsDataSource{}
 
-		Timeouts: &pluginsdk.ResourceTimeout{
-			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
-		},
+type KeyVaultSecretsDataSource struct{}
+
+type KeyVaultSecretsDataSourceModel struct {
+	KeyVaultId string                    `tfschema:"key_vault_id"`
+	Names      []string                  `tfschema:"names"`
+	Secrets    []KeyVaultNestedItemModel `tfschema:"secrets"`
+}
 
-		Schema: map[string]*pluginsdk.Schema{
-			"key_vault_id": {
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ValidateFunc: keyVaultValidate.VaultID,
-			},
-
-			"names": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Schema{
-					Type: pluginsdk.TypeString,
-				},
-			},
-
-			"secrets": {
-				Type:     pluginsdk.TypeList,
-				Computed: true,
-				Elem: &pluginsdk.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"name": {
-							Type:     pluginsdk.TypeString,
-							Computed: true,
-						},
-
-						"enabled": {
-							Type:     pluginsdk.TypeBool,
-							Computed: true,
-						},
-					},
-				},
-			},
+func (KeyVaultSecretsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: keyVaultValidate.VaultID,
 		},
 	}
 }
 
-func dataSourceKeyVaultSecretsRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	keyVaultsClient := meta.(*clients.Client).KeyVault
-	client := meta.(*clients.Client).KeyVault.ManagementClient
-	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
-	defer cancel()
+func (KeyVaultSecretsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"names": keyVaultNestedItemNamesSchema(),
 
-	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
-	if err != nil {
-		return err
+		"secrets": keyVaultNestedItemsSchema(),
 	}
+}
 
-	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
-	if err != nil {
-		return fmt.Errorf("fetching base vault url from id %q: %+v", *keyVaultId, err)
-	}
+func (KeyVaultSecretsDataSource) ModelObject() interface{} {
+	return &KeyVaultSecretsDataSourceModel{}
+}
 
-	secretList, err := client.GetSecretsComplete(ctx, *keyVaultBaseUri, utils.Int32(25))
-	if err != nil {
-		return fmt.Errorf("making Read request on Azure KeyVault %q: %+v", *keyVaultId, err)
-	}
+func (KeyVaultSecretsDataSource) ResourceType() string {
+	return "azurerm_key_vault_secrets"
+}
+
+func (KeyVaultSecretsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			client := metadata.Client.KeyVault.ManagementClient
 
-	d.SetId(keyVaultId.ID())
+			var model KeyVaultSecretsDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
 
-	var names []string
-	var secrets []map[string]interface{}
+			keyVaultId, err := parse.VaultID(model.KeyVaultId)
+			if err != nil {
+				return err
+			}
 
-	if secretList.Response().Value != nil {
-		for secretList.NotDone() {
-			for _, v := range *secretList.Response().Value {
-				name, err := parseNameFromSecretUrl(*v.ID)
-				if err != nil {
-					return err
+			keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+			if err != nil {
+				return fmt.Errorf("looking up Base URI for Secrets in %s: %+v", *keyVaultId, err)
+			}
+
+			iterator, err := client.GetSecretsComplete(ctx, *keyVaultBaseUri, utils.Int32(25))
+			if err != nil {
+				return fmt.Errorf("listing Secrets in %s: %+v", *keyVaultId, err)
+			}
+
+			model.Names, model.Secrets, err = listKeyVaultNestedItems[keyvault.SecretItem](ctx, &iterator, func(item keyvault.SecretItem) (*KeyVaultNestedItemModel, error) {
+				if item.ID == nil {
+					return nil, nil
 				}
-				names = append(names, *name)
-				secrets = append(secrets, expandSecrets(*name, v))
-				err = secretList.NextWithContext(ctx)
+
+				name, err := parseNameFromSecretUrl(*item.ID)
 				if err != nil {
-					return fmt.Errorf("listing secrets on Azure KeyVault %q: %+v", *keyVaultId, err)
+					return nil, err
 				}
-			}
-		}
-	}
 
-	d.Set("names", names)
-	d.Set("secrets", secrets)
-	d.Set("key_vault_id", keyVaultId.ID())
+				enabled := false
+				if item.Attributes != nil && item.Attributes.Enabled != nil {
+					enabled = *item.Attributes.Enabled
+				}
 
-	return nil
+				return &KeyVaultNestedItemModel{
+					Id:      *item.ID,
+					Name:    *name,
+					Enabled: enabled,
+				}, nil
+			})
+			if err != nil {
+				return fmt.Errorf("listing Secrets in %s: %+v", *keyVaultId, err)
+			}
+
+			model.KeyVaultId = keyVaultId.ID()
+			metadata.SetID(keyVaultId)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
 }
 
 func parseNameFromSecretUrl(input string) (*string, error) {
@@ -127,14 +122,3 @@ func parseNameFromSecretUrl(input string) (*string, error) {
 	}
 	return &segments[2], nil
 }
-
-func expandSecrets(name string, item keyvault.SecretItem) map[string]interface{} {
-	res := map[string]interface{}{
-		"id":   *item.ID,
-		"name": name,
-	}
-	if item.Attributes != nil && item.Attributes.Enabled != nil {
-		res["enabled"] = *item.Attributes.Enabled
-	}
-	return res
-}
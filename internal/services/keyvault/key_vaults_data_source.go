@@ -0,0 +1,207 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = KeyVaultsDataSource{}
+
+type KeyVaultsDataSource struct{}
+
+type KeyVaultsDataSourceModel struct {
+	SubscriptionId     string                   `tfschema:"subscription_id"`
+	ResourceGroupName  string                   `tfschema:"resource_group_name"`
+	Tags               map[string]string        `tfschema:"tags"`
+	RequireSingleMatch bool                     `tfschema:"require_single_match"`
+	KeyVaults          []KeyVaultsKeyVaultModel `tfschema:"key_vaults"`
+}
+
+type KeyVaultsKeyVaultModel struct {
+	Id                string            `tfschema:"id"`
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	Location          string            `tfschema:"location"`
+	VaultUri          string            `tfschema:"vault_uri"`
+	Tags              map[string]string `tfschema:"tags"`
+}
+
+func (KeyVaultsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"subscription_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupNameOptional(),
+
+		"tags": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"require_single_match": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (KeyVaultsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vaults": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"resource_group_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"location": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"vault_uri": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"tags": tags.SchemaDataSource(),
+				},
+			},
+		},
+	}
+}
+
+func (KeyVaultsDataSource) ModelObject() interface{} {
+	return &KeyVaultsDataSourceModel{}
+}
+
+func (KeyVaultsDataSource) ResourceType() string {
+	return "azurerm_key_vaults"
+}
+
+func (KeyVaultsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			keyVaultsClient := metadata.Client.KeyVault
+			resourcesClient := metadata.Client.Resource.ResourcesClient
+			vaultsClient := metadata.Client.KeyVault.VaultsClient
+
+			var model KeyVaultsDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if model.SubscriptionId == "" {
+				model.SubscriptionId = metadata.Client.Account.SubscriptionId
+			}
+			if model.SubscriptionId != metadata.Client.Account.SubscriptionId {
+				resourcesClient = metadata.Client.Resource.ResourcesClientForSubscription(model.SubscriptionId)
+				vaultsClient = metadata.Client.KeyVault.KeyVaultClientForSubscription(model.SubscriptionId)
+			}
+
+			var scope resourceids.Id = commonids.NewSubscriptionID(model.SubscriptionId)
+			if model.ResourceGroupName != "" {
+				scope = commonids.NewResourceGroupID(model.SubscriptionId, model.ResourceGroupName)
+			}
+
+			// filtering on tags can't be combined with filtering on the resource type, so the tags are matched below
+			filter := "resourceType eq 'Microsoft.KeyVault/vaults'"
+			var iterator resources.ListResultIterator
+			var err error
+			if model.ResourceGroupName != "" {
+				iterator, err = resourcesClient.ListByResourceGroupComplete(ctx, model.ResourceGroupName, filter, "", nil)
+			} else {
+				iterator, err = resourcesClient.ListComplete(ctx, filter, "", nil)
+			}
+			if err != nil {
+				return fmt.Errorf("listing Key Vaults within %s: %+v", scope, err)
+			}
+
+			model.KeyVaults, err = sdk.ListFromAutoRestIterator[resources.GenericResourceExpanded](ctx, &iterator, 0, func(v resources.GenericResourceExpanded) (*KeyVaultsKeyVaultModel, error) {
+				if v.ID == nil || !keyVaultTagsMatch(v.Tags, model.Tags) {
+					return nil, nil
+				}
+
+				id, err := parse.VaultID(*v.ID)
+				if err != nil {
+					return nil, fmt.Errorf("parsing %q: %+v", *v.ID, err)
+				}
+
+				// the Vault URI isn't returned when listing resources, so we need to retrieve each Key Vault
+				resp, err := vaultsClient.Get(ctx, id.ResourceGroup, id.Name)
+				if err != nil {
+					return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+				}
+				if resp.Properties == nil || resp.Properties.VaultURI == nil {
+					return nil, fmt.Errorf("retrieving %s: `properties.VaultUri` was nil", *id)
+				}
+				keyVaultsClient.AddToCache(*id, *resp.Properties.VaultURI)
+
+				return &KeyVaultsKeyVaultModel{
+					Id:                id.ID(),
+					Name:              id.Name,
+					ResourceGroupName: id.ResourceGroup,
+					Location:          location.NormalizeNilable(v.Location),
+					VaultUri:          *resp.Properties.VaultURI,
+					Tags:              tags.ToTypedObject(v.Tags),
+				}, nil
+			})
+			if err != nil {
+				return fmt.Errorf("iterating over Key Vaults within %s: %+v", scope, err)
+			}
+
+			if model.RequireSingleMatch && len(model.KeyVaults) != 1 {
+				return fmt.Errorf("expected a single Key Vault matching the specified criteria within %s but found %d", scope, len(model.KeyVaults))
+			}
+
+			metadata.SetID(scope)
+			return metadata.Encode(&model)
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// keyVaultTagsMatch returns whether all of the required tags are present with the same value
+func keyVaultTagsMatch(actual map[string]*string, required map[string]string) bool {
+	for requiredName, requiredValue := range required {
+		value, ok := actual[requiredName]
+		if !ok || value == nil || *value != requiredValue {
+			return false
+		}
+	}
+
+	return true
+}
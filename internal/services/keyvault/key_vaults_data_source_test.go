@@ -0,0 +1,144 @@
+package keyvault_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KeyVaultsDataSource struct{}
+
+func TestAccDataSourceKeyVaults_tags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vaults", "test")
+	r := KeyVaultsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.tags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("key_vaults.#").HasValue("1"),
+				check.That(data.ResourceName).Key("key_vaults.0.name").HasValue(fmt.Sprintf("acctestkv%s-prod", data.RandomString)),
+				check.That(data.ResourceName).Key("key_vaults.0.vault_uri").Exists(),
+				check.That(data.ResourceName).Key("key_vaults.0.tags.purpose").HasValue("platform-secrets"),
+				check.That(data.ResourceName).Key("key_vaults.0.tags.env").HasValue("prod"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceKeyVaults_resourceGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vaults", "test")
+	r := KeyVaultsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.resourceGroup(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("key_vaults.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceKeyVaults_requireSingleMatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_key_vaults", "test")
+	r := KeyVaultsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config:      r.requireSingleMatch(data),
+			ExpectError: regexp.MustCompile("expected a single Key Vault matching the specified criteria"),
+		},
+	})
+}
+
+func (KeyVaultsDataSource) tags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vaults" "test" {
+  tags = {
+    purpose  = "platform-secrets"
+    env      = "prod"
+    test_run = "%d"
+  }
+
+  depends_on = [azurerm_key_vault.prod, azurerm_key_vault.dev]
+}
+`, KeyVaultsDataSource{}.template(data), data.RandomInteger)
+}
+
+func (KeyVaultsDataSource) resourceGroup(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vaults" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+
+  depends_on = [azurerm_key_vault.prod, azurerm_key_vault.dev]
+}
+`, KeyVaultsDataSource{}.template(data))
+}
+
+func (KeyVaultsDataSource) requireSingleMatch(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_key_vaults" "test" {
+  resource_group_name  = azurerm_resource_group.test.name
+  require_single_match = true
+
+  tags = {
+    purpose = "platform-secrets"
+  }
+
+  depends_on = [azurerm_key_vault.prod, azurerm_key_vault.dev]
+}
+`, KeyVaultsDataSource{}.template(data))
+}
+
+func (KeyVaultsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-kv-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_key_vault" "prod" {
+  name                = "acctestkv%[3]s-prod"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  tenant_id           = data.azurerm_client_config.current.tenant_id
+  sku_name            = "standard"
+
+  tags = {
+    purpose  = "platform-secrets"
+    env      = "prod"
+    test_run = "%[1]d"
+  }
+}
+
+resource "azurerm_key_vault" "dev" {
+  name                = "acctestkv%[3]s-dev"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  tenant_id           = data.azurerm_client_config.current.tenant_id
+  sku_name            = "standard"
+
+  tags = {
+    purpose  = "platform-secrets"
+    env      = "dev"
+    test_run = "%[1]d"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,210 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/rickb777/date/period"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+// nestedItemPolicyCheckFunc validates the planned values of a Key Vault Certificate, Key or Secret
+// against the opt-in policies defined in the `key_vault` block within the Provider's `features` block
+type nestedItemPolicyCheckFunc func(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error
+
+// nestedItemPolicyCustomizeDiff returns a CustomizeDiffFunc which runs each of the specified checks
+// against the Key Vault policies configured in the Provider's `features` block.
+//
+// The checks are only run when the item is being created, or when the fields they inspect are changing,
+// so that enabling a policy doesn't block plans for existing items which would otherwise be unchanged.
+func nestedItemPolicyCustomizeDiff(checks ...nestedItemPolicyCheckFunc) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || client == nil {
+			return nil
+		}
+
+		var err error
+		for _, check := range checks {
+			if checkErr := check(d, client.Features.KeyVault); checkErr != nil {
+				err = multierror.Append(err, checkErr)
+			}
+		}
+		return err
+	}
+}
+
+// nestedItemPolicyExpirationDate checks the `expiration_date` and `not_before_date` fields used by
+// Key Vault Keys and Secrets against the `require_expiration_date` and `maximum_validity_period` policies
+func nestedItemPolicyExpirationDate(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error {
+	if !policy.RequireExpirationDate && policy.MaximumValidityPeriod == "" {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("expiration_date", "not_before_date") {
+		return nil
+	}
+	if !d.NewValueKnown("expiration_date") || !d.NewValueKnown("not_before_date") {
+		return nil
+	}
+
+	expirationDate := d.Get("expiration_date").(string)
+	if expirationDate == "" {
+		if policy.RequireExpirationDate {
+			return fmt.Errorf("`expiration_date` must be specified since `require_expiration_date` is enabled within the `key_vault` block of the `features` block")
+		}
+		return fmt.Errorf("`expiration_date` must be specified since `maximum_validity_period` is set within the `key_vault` block of the `features` block")
+	}
+
+	if policy.MaximumValidityPeriod == "" {
+		return nil
+	}
+
+	expires, _ := time.Parse(time.RFC3339, expirationDate) // validated by schema
+	start := time.Now()
+	if v := d.Get("not_before_date").(string); v != "" {
+		start, _ = time.Parse(time.RFC3339, v) // validated by schema
+	}
+
+	latestExpiry, err := nestedItemPolicyLatestExpiry(policy.MaximumValidityPeriod, start)
+	if err != nil {
+		return err
+	}
+	if expires.After(latestExpiry) {
+		return fmt.Errorf("`expiration_date` (%s) must be no later than %s since `maximum_validity_period` is set to %q within the `key_vault` block of the `features` block", expirationDate, latestExpiry.Format(time.RFC3339), policy.MaximumValidityPeriod)
+	}
+
+	return nil
+}
+
+// nestedItemPolicyValidityInMonths checks the `validity_in_months` field of a Key Vault Certificate's
+// `certificate_policy` against the `maximum_validity_period` policy
+func nestedItemPolicyValidityInMonths(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error {
+	if policy.MaximumValidityPeriod == "" {
+		return nil
+	}
+
+	key := "certificate_policy.0.x509_certificate_properties.0.validity_in_months"
+	if d.Id() != "" && !d.HasChange(key) {
+		return nil
+	}
+	if !d.NewValueKnown(key) {
+		return nil
+	}
+
+	validityInMonths, ok := d.GetOk(key)
+	if !ok {
+		return nil
+	}
+
+	start := time.Now()
+	latestExpiry, err := nestedItemPolicyLatestExpiry(policy.MaximumValidityPeriod, start)
+	if err != nil {
+		return err
+	}
+	if start.AddDate(0, validityInMonths.(int), 0).After(latestExpiry) {
+		return fmt.Errorf("`validity_in_months` (%d) exceeds the `maximum_validity_period` of %q set within the `key_vault` block of the `features` block", validityInMonths.(int), policy.MaximumValidityPeriod)
+	}
+
+	return nil
+}
+
+// nestedItemPolicyRequiredTags checks that each of the tags listed in the `required_tags` policy is specified
+func nestedItemPolicyRequiredTags(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error {
+	if len(policy.RequiredTags) == 0 {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("tags") {
+		return nil
+	}
+	if !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	missing := make([]string, 0)
+	for _, tag := range policy.RequiredTags {
+		if _, ok := tags[tag]; !ok {
+			missing = append(missing, fmt.Sprintf("%q", tag))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the tags %s must be specified since they're listed in `required_tags` within the `key_vault` block of the `features` block", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// nestedItemPolicyKey checks the `key_type`, `key_size` and `curve` fields of a Key Vault Key
+// against the `minimum_rsa_key_size` and `allowed_key_curves` policies
+func nestedItemPolicyKey(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error {
+	return nestedItemPolicyKeyProperties(d, policy, "")
+}
+
+// nestedItemPolicyCertificateKey checks the `key_properties` of a Key Vault Certificate's `certificate_policy`
+// against the `minimum_rsa_key_size` and `allowed_key_curves` policies
+func nestedItemPolicyCertificateKey(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures) error {
+	if _, ok := d.GetOk("certificate_policy.0.key_properties"); !ok {
+		return nil
+	}
+	return nestedItemPolicyKeyProperties(d, policy, "certificate_policy.0.key_properties.0.")
+}
+
+func nestedItemPolicyKeyProperties(d *pluginsdk.ResourceDiff, policy features.KeyVaultFeatures, prefix string) error {
+	if policy.MinimumRSAKeySize == 0 && len(policy.AllowedKeyCurves) == 0 {
+		return nil
+	}
+
+	keyTypeKey := prefix + "key_type"
+	keySizeKey := prefix + "key_size"
+	curveKey := prefix + "curve"
+	if d.Id() != "" && !d.HasChanges(keyTypeKey, keySizeKey, curveKey) {
+		return nil
+	}
+	if !d.NewValueKnown(keyTypeKey) {
+		return nil
+	}
+
+	keyType := keyvault.JSONWebKeyType(d.Get(keyTypeKey).(string))
+	switch keyType {
+	case keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeRSAHSM:
+		if policy.MinimumRSAKeySize == 0 || !d.NewValueKnown(keySizeKey) {
+			return nil
+		}
+		if keySize := d.Get(keySizeKey).(int); keySize != 0 && keySize < policy.MinimumRSAKeySize {
+			return fmt.Errorf("`%s` must be at least %d since `minimum_rsa_key_size` is set within the `key_vault` block of the `features` block, got %d", keySizeKey, policy.MinimumRSAKeySize, keySize)
+		}
+
+	case keyvault.JSONWebKeyTypeEC, keyvault.JSONWebKeyTypeECHSM:
+		if len(policy.AllowedKeyCurves) == 0 || !d.NewValueKnown(curveKey) {
+			return nil
+		}
+		curve := d.Get(curveKey).(string)
+		if curve == "" {
+			return nil
+		}
+		for _, allowed := range policy.AllowedKeyCurves {
+			if strings.EqualFold(curve, allowed) {
+				return nil
+			}
+		}
+		return fmt.Errorf("`%s` must be one of %q since `allowed_key_curves` is set within the `key_vault` block of the `features` block, got %q", curveKey, policy.AllowedKeyCurves, curve)
+	}
+
+	return nil
+}
+
+func nestedItemPolicyLatestExpiry(maximumValidityPeriod string, start time.Time) (time.Time, error) {
+	p, err := period.Parse(maximumValidityPeriod)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing `maximum_validity_period` %q: %+v", maximumValidityPeriod, err)
+	}
+
+	latestExpiry, _ := p.AddTo(start)
+	return latestExpiry, nil
+}
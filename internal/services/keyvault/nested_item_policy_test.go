@@ -0,0 +1,419 @@
+package keyvault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func nestedItemPolicyTestKeyPropertiesSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_type": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+		"key_size": {
+			Type:     pluginsdk.TypeInt,
+			Optional: true,
+		},
+		"curve": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+// nestedItemPolicyTestDiff runs the check against a new item with the specified configuration
+func nestedItemPolicyTestDiff(check nestedItemPolicyCheckFunc, policy features.KeyVaultFeatures, config map[string]interface{}) error {
+	resourceSchema := nestedItemPolicyTestKeyPropertiesSchema()
+	resourceSchema["expiration_date"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+	}
+	resourceSchema["not_before_date"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Optional: true,
+	}
+	resourceSchema["tags"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Optional: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+	resourceSchema["certificate_policy"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"key_properties": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: nestedItemPolicyTestKeyPropertiesSchema(),
+					},
+				},
+				"x509_certificate_properties": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"validity_in_months": {
+								Type:     pluginsdk.TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resource := &pluginsdk.Resource{
+		Schema:        resourceSchema,
+		CustomizeDiff: nestedItemPolicyCustomizeDiff(check),
+	}
+	client := &clients.Client{
+		Features: features.Default(),
+	}
+	client.Features.KeyVault = policy
+
+	_, err := resource.Diff(context.TODO(), nil, terraform.NewResourceConfigRaw(config), client)
+	return err
+}
+
+func TestNestedItemPolicyExpirationDate(t *testing.T) {
+	now := time.Now().UTC()
+	testData := []struct {
+		name   string
+		policy features.KeyVaultFeatures
+		config map[string]interface{}
+		error  bool
+	}{
+		{
+			name:   "policy disabled",
+			policy: features.KeyVaultFeatures{},
+			config: map[string]interface{}{},
+		},
+		{
+			name: "expiration date required and missing",
+			policy: features.KeyVaultFeatures{
+				RequireExpirationDate: true,
+			},
+			config: map[string]interface{}{},
+			error:  true,
+		},
+		{
+			name: "expiration date required and specified",
+			policy: features.KeyVaultFeatures{
+				RequireExpirationDate: true,
+			},
+			config: map[string]interface{}{
+				"expiration_date": now.AddDate(1, 0, 0).Format(time.RFC3339),
+			},
+		},
+		{
+			name: "maximum validity period and missing",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: map[string]interface{}{},
+			error:  true,
+		},
+		{
+			name: "within the maximum validity period",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: map[string]interface{}{
+				"expiration_date": now.AddDate(0, 6, 0).Format(time.RFC3339),
+			},
+		},
+		{
+			name: "exceeds the maximum validity period",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: map[string]interface{}{
+				"expiration_date": now.AddDate(2, 0, 0).Format(time.RFC3339),
+			},
+			error: true,
+		},
+		{
+			name: "within the maximum validity period from the not before date",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: map[string]interface{}{
+				"not_before_date": now.AddDate(1, 0, 0).Format(time.RFC3339),
+				"expiration_date": now.AddDate(1, 6, 0).Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		err := nestedItemPolicyTestDiff(nestedItemPolicyExpirationDate, v.policy, v.config)
+		if v.error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestNestedItemPolicyValidityInMonths(t *testing.T) {
+	validityInMonths := func(months int) map[string]interface{} {
+		return map[string]interface{}{
+			"certificate_policy": []interface{}{
+				map[string]interface{}{
+					"x509_certificate_properties": []interface{}{
+						map[string]interface{}{
+							"validity_in_months": months,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testData := []struct {
+		name   string
+		policy features.KeyVaultFeatures
+		config map[string]interface{}
+		error  bool
+	}{
+		{
+			name:   "policy disabled",
+			policy: features.KeyVaultFeatures{},
+			config: validityInMonths(120),
+		},
+		{
+			name: "within the maximum validity period",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: validityInMonths(12),
+		},
+		{
+			name: "exceeds the maximum validity period",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: validityInMonths(13),
+			error:  true,
+		},
+		{
+			name: "not specified",
+			policy: features.KeyVaultFeatures{
+				MaximumValidityPeriod: "P1Y",
+			},
+			config: map[string]interface{}{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		err := nestedItemPolicyTestDiff(nestedItemPolicyValidityInMonths, v.policy, v.config)
+		if v.error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestNestedItemPolicyRequiredTags(t *testing.T) {
+	testData := []struct {
+		name   string
+		policy features.KeyVaultFeatures
+		config map[string]interface{}
+		error  bool
+	}{
+		{
+			name:   "policy disabled",
+			policy: features.KeyVaultFeatures{},
+			config: map[string]interface{}{},
+		},
+		{
+			name: "all required tags specified",
+			policy: features.KeyVaultFeatures{
+				RequiredTags: []string{"owner", "environment"},
+			},
+			config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"owner":       "platform",
+					"environment": "production",
+					"other":       "value",
+				},
+			},
+		},
+		{
+			name: "required tag missing",
+			policy: features.KeyVaultFeatures{
+				RequiredTags: []string{"owner", "environment"},
+			},
+			config: map[string]interface{}{
+				"tags": map[string]interface{}{
+					"owner": "platform",
+				},
+			},
+			error: true,
+		},
+		{
+			name: "no tags specified",
+			policy: features.KeyVaultFeatures{
+				RequiredTags: []string{"owner"},
+			},
+			config: map[string]interface{}{},
+			error:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		err := nestedItemPolicyTestDiff(nestedItemPolicyRequiredTags, v.policy, v.config)
+		if v.error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestNestedItemPolicyKey(t *testing.T) {
+	keyProperties := func(keyType string, keySize int, curve string) map[string]interface{} {
+		return map[string]interface{}{
+			"key_type": keyType,
+			"key_size": keySize,
+			"curve":    curve,
+		}
+	}
+	certificateKeyProperties := func(keyType string, keySize int, curve string) map[string]interface{} {
+		return map[string]interface{}{
+			"certificate_policy": []interface{}{
+				map[string]interface{}{
+					"key_properties": []interface{}{
+						keyProperties(keyType, keySize, curve),
+					},
+				},
+			},
+		}
+	}
+	policy := features.KeyVaultFeatures{
+		MinimumRSAKeySize: 3072,
+		AllowedKeyCurves:  []string{"P-384", "P-521"},
+	}
+
+	testData := []struct {
+		name   string
+		check  nestedItemPolicyCheckFunc
+		policy features.KeyVaultFeatures
+		config map[string]interface{}
+		error  bool
+	}{
+		{
+			name:   "policy disabled",
+			check:  nestedItemPolicyKey,
+			policy: features.KeyVaultFeatures{},
+			config: keyProperties("RSA", 2048, ""),
+		},
+		{
+			name:   "RSA key meets the minimum size",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("RSA", 4096, ""),
+		},
+		{
+			name:   "RSA key below the minimum size",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("RSA", 2048, ""),
+			error:  true,
+		},
+		{
+			name:   "RSA-HSM key below the minimum size",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("RSA-HSM", 2048, ""),
+			error:  true,
+		},
+		{
+			name:   "EC key with an allowed curve",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("EC", 0, "P-384"),
+		},
+		{
+			name:   "EC key with an allowed curve in a different casing",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("EC", 0, "p-521"),
+		},
+		{
+			name:   "EC-HSM key with a curve which isn't allowed",
+			check:  nestedItemPolicyKey,
+			policy: policy,
+			config: keyProperties("EC-HSM", 0, "P-256"),
+			error:  true,
+		},
+		{
+			name:   "certificate policy disabled",
+			check:  nestedItemPolicyCertificateKey,
+			policy: features.KeyVaultFeatures{},
+			config: certificateKeyProperties("RSA", 2048, ""),
+		},
+		{
+			name:   "certificate RSA key meets the minimum size",
+			check:  nestedItemPolicyCertificateKey,
+			policy: policy,
+			config: certificateKeyProperties("RSA", 3072, ""),
+		},
+		{
+			name:   "certificate RSA key below the minimum size",
+			check:  nestedItemPolicyCertificateKey,
+			policy: policy,
+			config: certificateKeyProperties("RSA", 2048, ""),
+			error:  true,
+		},
+		{
+			name:   "certificate EC key with a curve which isn't allowed",
+			check:  nestedItemPolicyCertificateKey,
+			policy: policy,
+			config: certificateKeyProperties("EC", 0, "P-256"),
+			error:  true,
+		},
+		{
+			name:   "certificate without key properties",
+			check:  nestedItemPolicyCertificateKey,
+			policy: policy,
+			config: map[string]interface{}{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		err := nestedItemPolicyTestDiff(v.check, v.policy, v.config)
+		if v.error && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.error && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
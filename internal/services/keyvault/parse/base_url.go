@@ -0,0 +1,41 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// KeyVaultNameFromBaseUrl returns the name of the Key Vault (or Managed HSM) from the specified data plane URI,
+// for example `the-keyvault` from `https://the-keyvault.vault.azure.net/` - where the host must end with one
+// of the specified domain suffixes (e.g. `vault.azure.net` or `managedhsm.azure.net`)
+func KeyVaultNameFromBaseUrl(input string, domainSuffixes []string) (*string, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	host := strings.ToLower(uri.Hostname())
+	for _, domainSuffix := range domainSuffixes {
+		domainSuffix = strings.ToLower(strings.TrimPrefix(domainSuffix, "."))
+		if domainSuffix == "" || !strings.HasSuffix(host, fmt.Sprintf(".%s", domainSuffix)) {
+			continue
+		}
+
+		// the original casing of the name is retained, since this is used for lookups
+		name := uri.Hostname()[:len(host)-len(domainSuffix)-1]
+		if name == "" || strings.Contains(name, ".") {
+			return nil, fmt.Errorf("expected a URI in the format `the-keyvault-name.%s` but got %q", domainSuffix, uri.Host)
+		}
+
+		return &name, nil
+	}
+
+	return nil, fmt.Errorf("expected the host %q to end with one of the Key Vault domain suffixes %q", uri.Host, strings.Join(domainSuffixes, ", "))
+}
+
+// normalizeKeyVaultBaseUrl returns the Base URL for the Key Vault in a consistent format, removing any port
+// (which the Log Analytics service adds to the API returns) and ending in a trailing slash
+func normalizeKeyVaultBaseUrl(input *url.URL) string {
+	return fmt.Sprintf("%s://%s/", input.Scheme, input.Hostname())
+}
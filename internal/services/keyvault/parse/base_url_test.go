@@ -0,0 +1,80 @@
+package parse
+
+import "testing"
+
+func TestKeyVaultNameFromBaseUrl(t *testing.T) {
+	domainSuffixes := []string{"vault.azure.net", "managedhsm.azure.net"}
+	cases := []struct {
+		Input       string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:    "https://the-keyvault.vault.azure.net",
+			Expected: "the-keyvault",
+		},
+		{
+			Input:    "https://the-keyvault.vault.azure.net/",
+			Expected: "the-keyvault",
+		},
+		{
+			Input:    "https://the-keyvault.vault.azure.net:443/",
+			Expected: "the-keyvault",
+		},
+		{
+			Input:    "https://The-KeyVault.VAULT.azure.net/",
+			Expected: "The-KeyVault",
+		},
+		{
+			Input:    "https://the-hsm.managedhsm.azure.net/",
+			Expected: "the-hsm",
+		},
+		{
+			// sovereign clouds use a different domain suffix
+			Input:       "https://the-keyvault.vault.azure.cn/",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://vault.azure.net/",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://nested.the-keyvault.vault.azure.net/",
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range cases {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := KeyVaultNameFromBaseUrl(v.Input, domainSuffixes)
+		if err != nil {
+			if v.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %+v", err)
+		}
+
+		if v.ExpectError {
+			t.Fatalf("Expected an error but got %q", *actual)
+		}
+
+		if *actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, *actual)
+		}
+	}
+
+	// custom domain suffixes (e.g. for Azure China) should be used when specified
+	actual, err := KeyVaultNameFromBaseUrl("https://the-keyvault.vault.azure.cn/", []string{"vault.azure.cn"})
+	if err != nil {
+		t.Fatalf("Expected a value but got an error: %+v", err)
+	}
+	if *actual != "the-keyvault" {
+		t.Fatalf("Expected %q but got %q", "the-keyvault", *actual)
+	}
+}
@@ -17,12 +17,8 @@ func NewCertificateContactsID(keyVaultBaseUrl string) (*CertificateContactsId, e
 		return nil, fmt.Errorf("parsing %q: %+v", keyVaultBaseUrl, err)
 	}
 
-	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
-		keyVaultUrl.Host = hostParts[0]
-	}
-
 	return &CertificateContactsId{
-		KeyVaultBaseUrl: keyVaultUrl.String(),
+		KeyVaultBaseUrl: normalizeKeyVaultBaseUrl(keyVaultUrl),
 	}, nil
 }
 
@@ -57,7 +53,7 @@ func CertificateContactsID(input string) (*CertificateContactsId, error) {
 	}
 
 	id := CertificateContactsId{
-		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		KeyVaultBaseUrl: normalizeKeyVaultBaseUrl(idURL),
 	}
 
 	return &id, nil
@@ -33,7 +33,7 @@ func IssuerID(id string) (*IssuerId, error) {
 	}
 
 	issuerId := IssuerId{
-		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		KeyVaultBaseUrl: normalizeKeyVaultBaseUrl(idURL),
 		Name:            components[2],
 	}
 
@@ -17,18 +17,16 @@ type NestedItemId struct {
 	Version         string
 }
 
+// NewNestedItemID returns a NestedItemId for the specified Key Vault Base URL - which is normalized to the
+// format `{scheme}://{host}/`, removing any port, path or query string from the specified URL
 func NewNestedItemID(keyVaultBaseUrl, nestedItemType, name, version string) (*NestedItemId, error) {
 	keyVaultUrl, err := url.Parse(keyVaultBaseUrl)
 	if err != nil || keyVaultBaseUrl == "" {
 		return nil, fmt.Errorf("parsing %q: %+v", keyVaultBaseUrl, err)
 	}
-	// (@jackofallops) - Log Analytics service adds the port number to the API returns, so we strip it here
-	if hostParts := strings.Split(keyVaultUrl.Host, ":"); len(hostParts) > 1 {
-		keyVaultUrl.Host = hostParts[0]
-	}
 
 	return &NestedItemId{
-		KeyVaultBaseUrl: keyVaultUrl.String(),
+		KeyVaultBaseUrl: normalizeKeyVaultBaseUrl(keyVaultUrl),
 		NestedItemType:  nestedItemType,
 		Name:            name,
 		Version:         version,
@@ -89,6 +87,50 @@ func ParseOptionallyVersionedNestedItemID(input string) (*NestedItemId, error) {
 	return parseNestedItemId(input)
 }
 
+// ParseNestedItemIDWithDomainSuffixes parses a Key Vault Nested Item ID (such as a Certificate, Key or Secret)
+// containing a version into a NestedItemId object - validating that the host of the Key Vault ends with one
+// of the specified Domain Suffixes (e.g. `vault.azure.net`), when any are specified
+func ParseNestedItemIDWithDomainSuffixes(input string, domainSuffixes []string) (*NestedItemId, error) {
+	item, err := ParseNestedItemID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateNestedItemDomainSuffix(*item, domainSuffixes); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ParseOptionallyVersionedNestedItemIDWithDomainSuffixes parses a Key Vault Nested Item ID (such as a Certificate,
+// Key or Secret) optionally containing a version into a NestedItemId object - validating that the host of the
+// Key Vault ends with one of the specified Domain Suffixes (e.g. `vault.azure.net`), when any are specified
+func ParseOptionallyVersionedNestedItemIDWithDomainSuffixes(input string, domainSuffixes []string) (*NestedItemId, error) {
+	item, err := ParseOptionallyVersionedNestedItemID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateNestedItemDomainSuffix(*item, domainSuffixes); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func validateNestedItemDomainSuffix(item NestedItemId, domainSuffixes []string) error {
+	if len(domainSuffixes) == 0 {
+		return nil
+	}
+
+	if _, err := KeyVaultNameFromBaseUrl(item.KeyVaultBaseUrl, domainSuffixes); err != nil {
+		return fmt.Errorf("parsing the Key Vault Base URL for %s: %+v", item, err)
+	}
+
+	return nil
+}
+
 func parseNestedItemId(id string) (*NestedItemId, error) {
 	// versioned example: https://tharvey-keyvault.vault.azure.net/type/bird/fdf067c93bbb4b22bff4d8b7a9a56217
 	// versionless example: https://tharvey-keyvault.vault.azure.net/type/bird/
@@ -114,7 +156,7 @@ func parseNestedItemId(id string) (*NestedItemId, error) {
 	}
 
 	childId := NestedItemId{
-		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		KeyVaultBaseUrl: normalizeKeyVaultBaseUrl(idURL),
 		NestedItemType:  components[0],
 		Name:            components[1],
 		Version:         version,
@@ -30,6 +30,19 @@ func TestNewNestedItemID(t *testing.T) {
 			Expected:        "https://test.vault.azure.net/keys/test/testVersionString",
 			ExpectError:     false,
 		},
+		{
+			// the Base URL is normalized to `{scheme}://{host}/`
+			Scenario:        "valid, with path and query string",
+			keyVaultBaseUrl: "https://test.vault.azure.net:443/some/path?api-version=7.4",
+			Expected:        "https://test.vault.azure.net/keys/test/testVersionString",
+			ExpectError:     false,
+		},
+		{
+			Scenario:        "valid, retains the scheme",
+			keyVaultBaseUrl: "http://test.vault.azure.net",
+			Expected:        "http://test.vault.azure.net/keys/test/testVersionString",
+			ExpectError:     false,
+		},
 	}
 	for _, tc := range cases {
 		id, err := NewNestedItemID(tc.keyVaultBaseUrl, childType, childName, childVersion)
@@ -216,3 +229,131 @@ func TestParseOptionallyVersionedNestedItemID(t *testing.T) {
 		}
 	}
 }
+
+func TestParseNestedItemIDWithDomainSuffixes(t *testing.T) {
+	cases := []struct {
+		Input          string
+		DomainSuffixes []string
+		ExpectError    bool
+	}{
+		{
+			Input:          "https://my-keyvault.vault.azure.net/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net", "managedhsm.azure.net"},
+			ExpectError:    false,
+		},
+		{
+			Input:          "https://my-hsm.managedhsm.azure.net/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net", "managedhsm.azure.net"},
+			ExpectError:    false,
+		},
+		{
+			// the Domain Suffix for a different environment
+			Input:          "https://my-keyvault.vault.azure.cn/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net", "managedhsm.azure.net"},
+			ExpectError:    true,
+		},
+		{
+			Input:          "https://my-keyvault.vault.azure.cn/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.cn", "managedhsm.azure.cn"},
+			ExpectError:    false,
+		},
+		{
+			// a custom environment
+			Input:          "https://my-keyvault.vault.contoso.internal/keys/castle/1492",
+			DomainSuffixes: []string{"vault.contoso.internal"},
+			ExpectError:    false,
+		},
+		{
+			Input:          "https://my-keyvault.example.com/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    true,
+		},
+		{
+			// the name of the Key Vault is missing
+			Input:          "https://vault.azure.net/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    true,
+		},
+		{
+			// when no Domain Suffixes are available any host is allowed
+			Input:          "https://my-keyvault.example.com/keys/castle/1492",
+			DomainSuffixes: []string{},
+			ExpectError:    false,
+		},
+		{
+			// missing version
+			Input:          "https://my-keyvault.vault.azure.net/keys/castle",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		id, err := ParseNestedItemIDWithDomainSuffixes(tc.Input, tc.DomainSuffixes)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+		}
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but got %+v", tc.Input, id)
+		}
+
+		if tc.Input != id.ID() {
+			t.Fatalf("Expected 'ID()' to be '%s', got '%s'", tc.Input, id.ID())
+		}
+	}
+}
+
+func TestParseOptionallyVersionedNestedItemIDWithDomainSuffixes(t *testing.T) {
+	cases := []struct {
+		Input          string
+		DomainSuffixes []string
+		ExpectError    bool
+	}{
+		{
+			Input:          "https://my-keyvault.vault.azure.net/keys/castle",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    false,
+		},
+		{
+			Input:          "https://my-keyvault.vault.azure.net/keys/castle/1492",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    false,
+		},
+		{
+			Input:          "https://my-keyvault.vault.usgovcloudapi.net/keys/castle",
+			DomainSuffixes: []string{"vault.azure.net"},
+			ExpectError:    true,
+		},
+		{
+			Input:          "https://my-keyvault.vault.usgovcloudapi.net/keys/castle",
+			DomainSuffixes: []string{"vault.usgovcloudapi.net"},
+			ExpectError:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		id, err := ParseOptionallyVersionedNestedItemIDWithDomainSuffixes(tc.Input, tc.DomainSuffixes)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+		}
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but got %+v", tc.Input, id)
+		}
+
+		if tc.Input != id.ID() {
+			t.Fatalf("Expected 'ID()' to be '%s', got '%s'", tc.Input, id.ID())
+		}
+	}
+}
@@ -35,7 +35,7 @@ func SasDefinitionID(id string) (*SasDefinitionId, error) {
 	}
 
 	sasDefinitionId := SasDefinitionId{
-		KeyVaultBaseUrl:    fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		KeyVaultBaseUrl:    normalizeKeyVaultBaseUrl(idURL),
 		StorageAccountName: components[1],
 		Name:               components[3],
 	}
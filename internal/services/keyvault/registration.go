@@ -36,9 +36,6 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 		"azurerm_key_vault_key":                              dataSourceKeyVaultKey(),
 		"azurerm_key_vault_managed_hardware_security_module": dataSourceKeyVaultManagedHardwareSecurityModule(),
 		"azurerm_key_vault_secret":                           dataSourceKeyVaultSecret(),
-		"azurerm_key_vault_secrets":                          dataSourceKeyVaultSecrets(),
-		"customkv_key_vault":                                 dataSourceKeyVault(),
-		"azurerm_key_vault_certificates":                     dataSourceKeyVaultCertificates(),
 	}
 }
 
@@ -51,7 +48,6 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_key_vault_key":                                          resourceKeyVaultKey(),
 		"azurerm_key_vault_managed_hardware_security_module":             resourceKeyVaultManagedHardwareSecurityModule(),
 		"azurerm_key_vault_secret":                                       resourceKeyVaultSecret(),
-		"customkv_key_vault":                                             resourceKeyVault(),
 		"azurerm_key_vault_managed_storage_account":                      resourceKeyVaultManagedStorageAccount(),
 		"azurerm_key_vault_managed_storage_account_sas_token_definition": resourceKeyVaultManagedStorageAccountSasTokenDefinition(),
 	}
@@ -60,11 +56,21 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		EncryptedValueDataSource{},
+		KeyVaultEffectivePermissionsDataSource{},
+		KeyVaultDataSource{},
+		KeyVaultCertificateContactsDataSource{},
+		KeyVaultCertificateIssuersDataSource{},
+		KeyVaultCertificatesDataSource{},
+		KeyVaultJWKSDataSource{},
+		KeyVaultsDataSource{},
+		KeyVaultManagedStorageAccountSasTokenDataSource{},
+		KeyVaultSecretsDataSource{},
 	}
 }
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		KeyVaultCertificateContactsResource{},
+		KeyVaultResource{},
 	}
 }
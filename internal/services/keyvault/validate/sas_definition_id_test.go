@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestSasDefinitionId(t *testing.T) {
+	cases := []struct {
+		Input       string
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/storage/account1",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/storage/account1/sas",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/secrets/account1/sas/definition1",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/storage/account1/sas/definition1",
+			ExpectError: false,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/storage/account1/sas/definition1/XXX",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := SasDefinitionId(tc.Input, "example")
+		if tc.ExpectError && len(errors) == 0 {
+			t.Fatalf("Got no errors for input %q but expected some", tc.Input)
+		} else if !tc.ExpectError && len(errors) > 0 {
+			t.Fatalf("Got %d errors for input %q when didn't expect any", len(errors), tc.Input)
+		}
+	}
+}
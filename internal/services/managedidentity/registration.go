@@ -0,0 +1,45 @@
+package managedidentity
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type Registration struct {
+	autoRegistration
+}
+
+var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+
+func (r Registration) AssociatedGitHubLabel() string {
+	return "service/managed-identity"
+}
+
+// SupportedDataSources returns the supported Data Sources supported by this Service
+func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_user_assigned_identity": dataSourceArmUserAssignedIdentity(),
+	}
+}
+
+// SupportedResources returns the supported Resources supported by this Service
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{}
+}
+
+// DataSources returns the Typed Data Sources supported by this Service, which are generated
+// (via autoRegistration) alongside any defined by hand
+func (r Registration) DataSources() []sdk.DataSource {
+	dataSources := []sdk.DataSource{}
+	dataSources = append(dataSources, r.autoRegistration.DataSources()...)
+	return dataSources
+}
+
+// Resources returns the Typed Resources supported by this Service, which are generated
+// (via autoRegistration) alongside any defined by hand
+func (r Registration) Resources() []sdk.Resource {
+	resources := []sdk.Resource{}
+	resources = append(resources, r.autoRegistration.Resources()...)
+	return resources
+}
@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type Registration struct{}
+
+var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+
+func (r Registration) AssociatedGitHubLabel() string {
+	return "service/resources"
+}
+
+// Name is the name of this Service
+func (r Registration) Name() string {
+	return "Resources"
+}
+
+// WebsiteCategories returns a list of categories which can be used for the sidebar
+func (r Registration) WebsiteCategories() []string {
+	return []string{
+		"Base",
+		"Template",
+	}
+}
+
+// SupportedDataSources returns the supported Data Sources supported by this Service
+func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_resource_group": dataSourceResourceGroup(),
+		"azurerm_resources":      dataSourceResources(),
+	}
+}
+
+// SupportedResources returns the supported Resources supported by this Service
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_resource_group": resourceResourceGroup(),
+	}
+}
+
+func (r Registration) DataSources() []sdk.DataSource {
+	return []sdk.DataSource{}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ResourceDeploymentScriptAzureCliResource{},
+		ResourceDeploymentScriptAzurePowerShellResource{},
+		ResourceProviderRegistrationResource{},
+	}
+}
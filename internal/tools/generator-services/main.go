@@ -3,14 +3,15 @@ package main
 import (
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
-	"reflect"
 	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk" // nolint: typecheck
 )
 
 // Packages in this list are deprecated and cannot be run due to breaking API changes
@@ -34,14 +35,23 @@ func main() {
 		return
 	}
 
+	// the Service Registrations are discovered from the source of each Service Package (rather than those compiled
+	// into this generator) so that each of the generators below uses the same, up-to-date, list of Services
+	servicesDirectory := filepath.Join(*filePath, "internal", "services")
+	services, err := discoverServiceRegistrations(servicesDirectory)
+	if err != nil {
+		panic(err)
+	}
+
 	generators := []generator{
+		serviceRegistrationsGenerator{},
 		githubLabelsGenerator{},
 		teamCityServicesListGenerator{},
 		websiteCategoriesGenerator{},
 	}
 	for _, value := range generators {
 		outputFile := value.outputPath(*filePath)
-		if err := value.run(outputFile, packagesToSkip); err != nil {
+		if err := value.run(outputFile, services, packagesToSkip); err != nil {
 			panic(err)
 		}
 	}
@@ -49,7 +59,7 @@ func main() {
 
 type generator interface {
 	outputPath(rootDirectory string) string
-	run(outputFileName string, packagesToSkip map[string]struct{}) error
+	run(outputFileName string, services []serviceRegistration, packagesToSkip map[string]struct{}) error
 }
 
 const githubLabelsTemplate = `# NOTE: this file is generated via 'make generate'
@@ -69,32 +79,15 @@ func (githubLabelsGenerator) outputPath(rootDirectory string) string {
 	return fmt.Sprintf("%s/.github/labeler-pull-request-triage.yml", rootDirectory)
 }
 
-func (githubLabelsGenerator) run(outputFileName string, _ map[string]struct{}) error {
+func (githubLabelsGenerator) run(outputFileName string, services []serviceRegistration, _ map[string]struct{}) error {
 	packagesToLabel := make(map[string]string)
-	// combine and unique these
-	for _, service := range provider.SupportedTypedServices() {
-		v, ok := service.(sdk.TypedServiceRegistrationWithAGitHubLabel)
-		if !ok {
+	for _, service := range services {
+		if service.gitHubLabel == "" {
 			// skipping since this doesn't implement the label interface
 			continue
 		}
 
-		info := reflect.TypeOf(service)
-		packageSegments := strings.Split(info.PkgPath(), "/")
-		packageName := packageSegments[len(packageSegments)-1]
-		packagesToLabel[packageName] = v.AssociatedGitHubLabel()
-	}
-	for _, service := range provider.SupportedUntypedServices() {
-		v, ok := service.(sdk.UntypedServiceRegistrationWithAGitHubLabel)
-		if !ok {
-			// skipping since this doesn't implement the label interface
-			continue
-		}
-
-		info := reflect.TypeOf(service)
-		packageSegments := strings.Split(info.PkgPath(), "/")
-		packageName := packageSegments[len(packageSegments)-1]
-		packagesToLabel[packageName] = v.AssociatedGitHubLabel()
+		packagesToLabel[service.packageName] = service.gitHubLabel
 	}
 
 	// labels can be present in more than one package, so we need to group them
@@ -142,7 +135,7 @@ func (teamCityServicesListGenerator) outputPath(rootDirectory string) string {
 	return fmt.Sprintf("%s/.teamcity/components/generated/services.kt", rootDirectory)
 }
 
-func (teamCityServicesListGenerator) run(outputFileName string, packagesToSkip map[string]struct{}) error {
+func (teamCityServicesListGenerator) run(outputFileName string, services []serviceRegistration, packagesToSkip map[string]struct{}) error {
 	template := `// NOTE: this is Generated from the Service Definitions - manual changes will be lost
 //       to re-generate this file, run 'make generate' in the root of the repository
 var services = mapOf(
@@ -150,43 +143,26 @@ var services = mapOf(
 )`
 	items := make([]string, 0)
 
-	services := make(map[string]string)
+	packageNames := make(map[string]string)
 	serviceNames := make([]string, 0)
-
-	// combine and unique these
-	for _, service := range provider.SupportedTypedServices() {
-		info := reflect.TypeOf(service)
-		packageSegments := strings.Split(info.PkgPath(), "/")
-		packageName := packageSegments[len(packageSegments)-1]
-		serviceName := service.Name()
-
-		// Service Registrations are reused across Typed and Untyped Services now
-		if _, exists := services[serviceName]; exists {
+	for _, service := range services {
+		if service.name == "" {
 			continue
 		}
 
-		services[serviceName] = packageName
-		serviceNames = append(serviceNames, serviceName)
-	}
-	for _, service := range provider.SupportedUntypedServices() {
-		info := reflect.TypeOf(service)
-		packageSegments := strings.Split(info.PkgPath(), "/")
-		packageName := packageSegments[len(packageSegments)-1]
-		serviceName := service.Name()
-
 		// Service Registrations are reused across Typed and Untyped Services now
-		if _, exists := services[serviceName]; exists {
+		if _, exists := packageNames[service.name]; exists {
 			continue
 		}
 
-		services[serviceName] = packageName
-		serviceNames = append(serviceNames, serviceName)
+		packageNames[service.name] = service.packageName
+		serviceNames = append(serviceNames, service.name)
 	}
 
 	// then ensure these are sorted so they're alphabetical
 	sort.Strings(serviceNames)
 	for _, serviceName := range serviceNames {
-		packageName := services[serviceName]
+		packageName := packageNames[serviceName]
 		if _, shouldSkip := packagesToSkip[packageName]; shouldSkip {
 			continue
 		}
@@ -199,27 +175,280 @@ var services = mapOf(
 	return writeToFile(outputFileName, formatted)
 }
 
+type serviceRegistrationsGenerator struct{}
+
+func (serviceRegistrationsGenerator) outputPath(rootDirectory string) string {
+	return fmt.Sprintf("%s/internal/provider/services_gen.go", rootDirectory)
+}
+
+const serviceRegistrationsTemplate = `package provider
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+%[1]s
+)
+
+func autoRegisteredTypedServices() []sdk.TypedServiceRegistration {
+	return []sdk.TypedServiceRegistration{
+%[2]s
+	}
+}
+
+func autoRegisteredUntypedServices() []sdk.UntypedServiceRegistration {
+	return []sdk.UntypedServiceRegistration{
+%[3]s
+	}
+}
+`
+
+func (serviceRegistrationsGenerator) run(outputFileName string, services []serviceRegistration, _ map[string]struct{}) error {
+	imports := []string{
+		fmt.Sprintf("\t%q", "github.com/hashicorp/terraform-provider-azurerm/internal/sdk"),
+	}
+	typed := make([]string, 0)
+	untyped := make([]string, 0)
+	for _, registration := range services {
+		imports = append(imports, fmt.Sprintf("\t%q", "github.com/hashicorp/terraform-provider-azurerm/internal/services/"+registration.directoryName))
+		item := fmt.Sprintf("\t\t%s.Registration{},", registration.packageName)
+		if registration.typed {
+			typed = append(typed, item)
+		}
+		if registration.untyped {
+			untyped = append(untyped, item)
+		}
+	}
+
+	contents := fmt.Sprintf(serviceRegistrationsTemplate, strings.Join(imports, "\n"), strings.Join(typed, "\n"), strings.Join(untyped, "\n"))
+	formatted, err := format.Source([]byte(contents))
+	if err != nil {
+		return fmt.Errorf("formatting %q: %+v", outputFileName, err)
+	}
+
+	return writeToFile(outputFileName, string(formatted))
+}
+
+type serviceRegistration struct {
+	// directoryName is the name of the directory containing the Service Package within `internal/services`
+	directoryName string
+	packageName   string
+
+	// typed and untyped specify whether the Registration implements the methods for a
+	// TypedServiceRegistration and/or an UntypedServiceRegistration
+	typed   bool
+	untyped bool
+
+	// name, websiteCategories and gitHubLabel are the values returned from the `Name`, `WebsiteCategories`
+	// and `AssociatedGitHubLabel` methods of the Registration
+	name              string
+	websiteCategories []string
+	gitHubLabel       string
+}
+
+// discoverServiceRegistrations returns the Service Registration for each Service Package within the directory
+func discoverServiceRegistrations(servicesDirectory string) ([]serviceRegistration, error) {
+	entries, err := os.ReadDir(servicesDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Service Packages within %q: %+v", servicesDirectory, err)
+	}
+
+	services := make([]serviceRegistration, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		registration, err := findServiceRegistration(filepath.Join(servicesDirectory, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("finding the Service Registration for %q: %+v", entry.Name(), err)
+		}
+		if registration == nil {
+			continue
+		}
+
+		registration.directoryName = entry.Name()
+		services = append(services, *registration)
+	}
+
+	return services, nil
+}
+
+// findServiceRegistration returns the exported `Registration` type within the Service Package, if one exists
+func findServiceRegistration(directory string) (*serviceRegistration, error) {
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, directory, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for packageName, pkg := range packages {
+		// the methods and embedded types for each type within the package, since the methods for
+		// the Registration can be promoted from an embedded type (e.g. a generated `autoRegistration`)
+		methods := make(map[string]map[string]*ast.FuncDecl)
+		embeddedTypes := make(map[string][]string)
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch v := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range v.Specs {
+						typeSpec, ok := spec.(*ast.TypeSpec)
+						if !ok {
+							continue
+						}
+						if _, ok := methods[typeSpec.Name.Name]; !ok {
+							methods[typeSpec.Name.Name] = make(map[string]*ast.FuncDecl)
+						}
+						if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+							for _, field := range structType.Fields.List {
+								if len(field.Names) == 0 {
+									embeddedTypes[typeSpec.Name.Name] = append(embeddedTypes[typeSpec.Name.Name], receiverTypeName(field.Type))
+								}
+							}
+						}
+					}
+				case *ast.FuncDecl:
+					if v.Recv == nil || len(v.Recv.List) != 1 {
+						continue
+					}
+					typeName := receiverTypeName(v.Recv.List[0].Type)
+					if _, ok := methods[typeName]; !ok {
+						methods[typeName] = make(map[string]*ast.FuncDecl)
+					}
+					methods[typeName][v.Name.Name] = v
+				}
+			}
+		}
+
+		if _, found := methods["Registration"]; !found {
+			continue
+		}
+
+		var findMethod func(typeName, methodName string) *ast.FuncDecl
+		findMethod = func(typeName, methodName string) *ast.FuncDecl {
+			if method, ok := methods[typeName][methodName]; ok {
+				return method
+			}
+			for _, embedded := range embeddedTypes[typeName] {
+				if method := findMethod(embedded, methodName); method != nil {
+					return method
+				}
+			}
+			return nil
+		}
+		hasMethods := func(names ...string) bool {
+			for _, name := range names {
+				if findMethod("Registration", name) == nil {
+					return false
+				}
+			}
+			return true
+		}
+
+		registration := serviceRegistration{
+			packageName: packageName,
+			typed:       hasMethods("DataSources", "Resources"),
+			untyped:     hasMethods("SupportedDataSources", "SupportedResources"),
+		}
+		if method := findMethod("Registration", "Name"); method != nil {
+			if registration.name, err = stringLiteralReturnedFrom(method); err != nil {
+				return nil, fmt.Errorf("parsing `Name`: %+v", err)
+			}
+		}
+		if method := findMethod("Registration", "AssociatedGitHubLabel"); method != nil {
+			if registration.gitHubLabel, err = stringLiteralReturnedFrom(method); err != nil {
+				return nil, fmt.Errorf("parsing `AssociatedGitHubLabel`: %+v", err)
+			}
+		}
+		if method := findMethod("Registration", "WebsiteCategories"); method != nil {
+			if registration.websiteCategories, err = stringSliceLiteralReturnedFrom(method); err != nil {
+				return nil, fmt.Errorf("parsing `WebsiteCategories`: %+v", err)
+			}
+		}
+
+		return &registration, nil
+	}
+
+	return nil, nil
+}
+
+// returnedExpression returns the expression returned from a method containing only a return statement,
+// which is how the metadata for a Service Registration is defined
+func returnedExpression(method *ast.FuncDecl) (ast.Expr, error) {
+	if method.Body == nil || len(method.Body.List) != 1 {
+		return nil, fmt.Errorf("expected a single return statement")
+	}
+	returnStmt, ok := method.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(returnStmt.Results) != 1 {
+		return nil, fmt.Errorf("expected a single return statement")
+	}
+	return returnStmt.Results[0], nil
+}
+
+// stringLiteralReturnedFrom returns the string literal returned from the method
+func stringLiteralReturnedFrom(method *ast.FuncDecl) (string, error) {
+	expr, err := returnedExpression(method)
+	if err != nil {
+		return "", err
+	}
+
+	return stringLiteral(expr)
+}
+
+// stringSliceLiteralReturnedFrom returns the values within the `[]string` literal returned from the method
+func stringSliceLiteralReturnedFrom(method *ast.FuncDecl) ([]string, error) {
+	expr, err := returnedExpression(method)
+	if err != nil {
+		return nil, err
+	}
+	compositeLit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, fmt.Errorf("expected a `[]string` literal to be returned")
+	}
+
+	out := make([]string, 0)
+	for _, elt := range compositeLit.Elts {
+		value, err := stringLiteral(elt)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+func stringLiteral(expr ast.Expr) (string, error) {
+	basicLit, ok := expr.(*ast.BasicLit)
+	if !ok || basicLit.Kind != token.STRING {
+		return "", fmt.Errorf("expected a string literal")
+	}
+
+	return strconv.Unquote(basicLit.Value)
+}
+
+func receiverTypeName(input ast.Expr) string {
+	if v, ok := input.(*ast.StarExpr); ok {
+		input = v.X
+	}
+	if v, ok := input.(*ast.Ident); ok {
+		return v.Name
+	}
+	return ""
+}
+
 type websiteCategoriesGenerator struct{}
 
 func (websiteCategoriesGenerator) outputPath(rootDirectory string) string {
 	return fmt.Sprintf("%s/website/allowed-subcategories", rootDirectory)
 }
 
-func (websiteCategoriesGenerator) run(outputFileName string, _ map[string]struct{}) error {
+func (websiteCategoriesGenerator) run(outputFileName string, services []serviceRegistration, _ map[string]struct{}) error {
 	websiteCategories := make([]string, 0)
 
 	// get a distinct list
-	for _, service := range provider.SupportedTypedServices() {
-		for _, category := range service.WebsiteCategories() {
-			if contains(websiteCategories, category) {
-				continue
-			}
-
-			websiteCategories = append(websiteCategories, category)
-		}
-	}
-	for _, service := range provider.SupportedUntypedServices() {
-		for _, category := range service.WebsiteCategories() {
+	for _, service := range services {
+		for _, category := range service.websiteCategories {
 			if contains(websiteCategories, category) {
 				continue
 			}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverServiceRegistrations(t *testing.T) {
+	servicesDirectory := t.TempDir()
+	files := map[string]string{
+		"example/registration.go": `package example
+
+type Registration struct {
+	autoRegistration
+}
+
+func (r Registration) AssociatedGitHubLabel() string {
+	return "service/example"
+}
+
+func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
+	return nil
+}
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return nil
+}
+`,
+		"example/registration_gen.go": `package example
+
+type autoRegistration struct {
+}
+
+func (autoRegistration) Name() string {
+	return "Example"
+}
+
+func (autoRegistration) DataSources() []sdk.DataSource {
+	return nil
+}
+
+func (autoRegistration) Resources() []sdk.Resource {
+	return nil
+}
+
+func (autoRegistration) WebsiteCategories() []string {
+	return []string{
+		"Example",
+		"Other",
+	}
+}
+`,
+		"unregistered/client.go": `package unregistered
+
+type Client struct{}
+`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(servicesDirectory, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("creating %q: %+v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("writing %q: %+v", path, err)
+		}
+	}
+
+	actual, err := discoverServiceRegistrations(servicesDirectory)
+	if err != nil {
+		t.Fatalf("discovering the Service Registrations: %+v", err)
+	}
+
+	expected := []serviceRegistration{
+		{
+			directoryName:     "example",
+			packageName:       "example",
+			typed:             true,
+			untyped:           true,
+			name:              "Example",
+			websiteCategories: []string{"Example", "Other"},
+			gitHubLabel:       "service/example",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestDiscoverServiceRegistrationsRequiresLiterals(t *testing.T) {
+	servicesDirectory := t.TempDir()
+	path := filepath.Join(servicesDirectory, "example", "registration.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("creating %q: %+v", filepath.Dir(path), err)
+	}
+	contents := `package example
+
+const serviceName = "Example"
+
+type Registration struct{}
+
+func (r Registration) Name() string {
+	return serviceName
+}
+`
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("writing %q: %+v", path, err)
+	}
+
+	if _, err := discoverServiceRegistrations(servicesDirectory); err == nil {
+		t.Fatalf("expected an error when the Name isn't a string literal")
+	}
+}